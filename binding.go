@@ -0,0 +1,103 @@
+package chi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/database/orm"
+)
+
+// ErrRouteModelNotFound can be returned by a RouteBinder to answer the request with 404.
+var ErrRouteModelNotFound = errors.New("route model not found")
+
+// RouteBinder resolves the value of a route parameter, e.g. to a model. A nil result, or an error
+// wrapping ErrRouteModelNotFound or orm.ErrRecordNotFound, answers the request with 404 and other
+// errors with 500.
+type RouteBinder func(ctx contractshttp.Context, value string) (any, error)
+
+// Bind registers the binder of a route parameter, the result is resolved before the handler of every
+// route with the parameter runs and can be retrieved with ContextRequest.RouteModel.
+func (r *Route) Bind(param string, binder RouteBinder) {
+	if r.instance.binders == nil {
+		r.instance.binders = make(map[string]RouteBinder)
+	}
+
+	r.instance.binders[param] = binder
+}
+
+// Model binds a route parameter to the model, such as models.User{}, whose column equals the
+// parameter through OrmFacade. The column defaults to id. The orm is resolved when a request with the
+// parameter is bound, so routes can be registered before the database is booted and applications
+// without a database aren't affected.
+func (r *Route) Model(param string, model any, column ...string) {
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Pointer {
+		modelType = modelType.Elem()
+	}
+
+	key := "id"
+	if len(column) > 0 && column[0] != "" {
+		key = column[0]
+	}
+
+	r.Bind(param, func(ctx contractshttp.Context, value string) (any, error) {
+		ormFacade := OrmFacade
+		if ormFacade == nil && App != nil {
+			ormFacade = App.MakeOrm()
+		}
+		if ormFacade == nil {
+			return nil, errors.New("orm facade is not initialized")
+		}
+
+		dest := reflect.New(modelType).Interface()
+		if err := ormFacade.WithContext(ctx).Query().Where(key+" = ?", value).FirstOrFail(dest); err != nil {
+			return nil, err
+		}
+
+		return dest, nil
+	})
+}
+
+// bindRouteModels resolves the bound parameters of the matched route, it answers the request and
+// returns false when a parameter can't be resolved.
+func bindRouteModels(ctx *Context) bool {
+	if len(ctx.instance.binders) == 0 {
+		return true
+	}
+	chiCtx := chi.RouteContext(ctx.r.Context())
+	if chiCtx == nil {
+		return true
+	}
+
+	for k, param := range chiCtx.URLParams.Keys {
+		binder, exist := ctx.instance.binders[param]
+		if !exist {
+			continue
+		}
+
+		model, err := binder(ctx, chiCtx.URLParams.Values[k])
+		switch {
+		case err == nil && model != nil:
+			if ctx.models == nil {
+				ctx.models = make(map[string]any)
+			}
+			ctx.models[param] = model
+			continue
+		case err == nil, errors.Is(err, ErrRouteModelNotFound), errors.Is(err, orm.ErrRecordNotFound):
+			ctx.instance.mux.NotFoundHandler().ServeHTTP(ctx.w, ctx.r)
+		default:
+			if LogFacade != nil {
+				LogFacade.WithContext(ctx).Error(fmt.Errorf("bind route parameter %s error: %w", param, err))
+			}
+			abortWithStatus(ctx.instance, ctx.w, ctx.r, http.StatusInternalServerError)
+		}
+
+		return false
+	}
+
+	return true
+}
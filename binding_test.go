@@ -0,0 +1,219 @@
+package chi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/database/orm"
+	configmocks "github.com/goravel/framework/mocks/config"
+	ormmocks "github.com/goravel/framework/mocks/database/orm"
+	foundationmocks "github.com/goravel/framework/mocks/foundation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type bindingUser struct {
+	ID   string
+	Name string
+}
+
+func TestRouteBinding(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		fallback   bool
+		expectCode int
+		expectBody string
+	}{
+		{
+			name:       "bound",
+			url:        "/users/1",
+			expectCode: http.StatusOK,
+			expectBody: "goravel",
+		},
+		{
+			name:       "unbound parameter",
+			url:        "/posts/1",
+			expectCode: http.StatusOK,
+			expectBody: "<nil>",
+		},
+		{
+			name:       "nil result",
+			url:        "/users/2",
+			expectCode: http.StatusNotFound,
+			expectBody: "404 page not found\n",
+		},
+		{
+			name:       "not found error",
+			url:        "/users/3",
+			expectCode: http.StatusNotFound,
+			expectBody: "404 page not found\n",
+		},
+		{
+			name:       "not found through fallback",
+			url:        "/users/3",
+			fallback:   true,
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name:       "error",
+			url:        "/users/4",
+			expectCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			if test.fallback {
+				route.Fallback(func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusNotFound, "fallback")
+				})
+			}
+			route.Bind("user", func(ctx contractshttp.Context, value string) (any, error) {
+				switch value {
+				case "1":
+					return &bindingUser{ID: value, Name: "goravel"}, nil
+				case "2":
+					return nil, nil
+				case "3":
+					return nil, fmt.Errorf("user %s: %w", value, ErrRouteModelNotFound)
+				}
+
+				return nil, errors.New("database is down")
+			})
+			route.Get("/users/{user}", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, ctx.Request().(*ContextRequest).RouteModel("user").(*bindingUser).Name)
+			})
+			route.Get("/posts/{post}", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, fmt.Sprint(ctx.Request().(*ContextRequest).RouteModel("post")))
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.url, nil)
+			assert.Nil(t, err)
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectBody, w.Body.String())
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouteBinding_Model(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		setup      func(mockQuery *ormmocks.Query)
+		expectCode int
+		expectBody string
+	}{
+		{
+			name: "found",
+			url:  "/users/goravel",
+			setup: func(mockQuery *ormmocks.Query) {
+				mockQuery.EXPECT().Where("name = ?", "goravel").Return(mockQuery).Once()
+				mockQuery.EXPECT().FirstOrFail(mock.AnythingOfType("*chi.bindingUser")).Run(func(dest any) {
+					dest.(*bindingUser).ID = "1"
+				}).Return(nil).Once()
+			},
+			expectCode: http.StatusOK,
+			expectBody: "1",
+		},
+		{
+			name: "not found",
+			url:  "/users/laravel",
+			setup: func(mockQuery *ormmocks.Query) {
+				mockQuery.EXPECT().Where("name = ?", "laravel").Return(mockQuery).Once()
+				mockQuery.EXPECT().FirstOrFail(mock.AnythingOfType("*chi.bindingUser")).Return(orm.ErrRecordNotFound).Once()
+			},
+			expectCode: http.StatusNotFound,
+			expectBody: "404 page not found\n",
+		},
+	}
+
+	originOrmFacade := OrmFacade
+	defer func() {
+		OrmFacade = originOrmFacade
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+			mockOrm := ormmocks.NewOrm(t)
+			mockQuery := ormmocks.NewQuery(t)
+			mockOrm.EXPECT().WithContext(mock.Anything).Return(mockOrm).Once()
+			mockOrm.EXPECT().Query().Return(mockQuery).Once()
+			test.setup(mockQuery)
+			OrmFacade = mockOrm
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			route.Model("user", bindingUser{}, "name")
+			route.Get("/users/{user}", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, ctx.Request().(*ContextRequest).RouteModel("user").(*bindingUser).ID)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.url, nil)
+			assert.Nil(t, err)
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectBody, w.Body.String())
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouteBinding_ModelResolvesOrmOnRequest(t *testing.T) {
+	originOrmFacade, originApp := OrmFacade, App
+	OrmFacade, App = nil, nil
+	defer func() {
+		OrmFacade, App = originOrmFacade, originApp
+	}()
+
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	route.Model("user", &bindingUser{})
+	route.Get("/users/{user}", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().String(http.StatusOK, ctx.Request().(*ContextRequest).RouteModel("user").(*bindingUser).Name)
+	})
+
+	mockQuery := ormmocks.NewQuery(t)
+	mockQuery.EXPECT().Where("id = ?", "1").Return(mockQuery).Once()
+	mockQuery.EXPECT().FirstOrFail(mock.AnythingOfType("*chi.bindingUser")).Run(func(dest any) {
+		dest.(*bindingUser).Name = "goravel"
+	}).Return(nil).Once()
+	mockOrm := ormmocks.NewOrm(t)
+	mockOrm.EXPECT().WithContext(mock.Anything).Return(mockOrm).Once()
+	mockOrm.EXPECT().Query().Return(mockQuery).Once()
+	mockApp := foundationmocks.NewApplication(t)
+	mockApp.EXPECT().MakeOrm().Return(mockOrm).Once()
+	App = mockApp
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest("GET", "/users/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "goravel", w.Body.String())
+	mockConfig.AssertExpectations(t)
+}
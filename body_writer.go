@@ -0,0 +1,176 @@
+package chi
+
+import (
+	"io"
+	"net/http"
+)
+
+// bodyResponseWriter writes through a BodyWriter, wrapBodyWriter adds the optional interfaces the
+// wrapped writer really implements.
+type bodyResponseWriter struct {
+	w *BodyWriter
+}
+
+func (w bodyResponseWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+func (w bodyResponseWriter) Write(b []byte) (int, error) {
+	return w.w.Write(b)
+}
+
+func (w bodyResponseWriter) WriteHeader(code int) {
+	w.w.WriteHeader(code)
+}
+
+func (w bodyResponseWriter) WriteString(s string) (int, error) {
+	return w.w.WriteString(s)
+}
+
+// Unwrap lets http.NewResponseController reach the wrapped writer, for deadlines and full-duplex.
+func (w bodyResponseWriter) Unwrap() http.ResponseWriter {
+	return w.w.ResponseWriter
+}
+
+// bodyReaderFrom copies bodies through the BodyWriter while it captures them, and with the
+// io.ReaderFrom of the wrapped writer otherwise so sendfile can be used.
+type bodyReaderFrom struct {
+	w          *BodyWriter
+	readerFrom io.ReaderFrom
+}
+
+func (r bodyReaderFrom) ReadFrom(src io.Reader) (int64, error) {
+	if r.w.capturing() {
+		return io.Copy(r.w, src)
+	}
+
+	n, err := r.readerFrom.ReadFrom(src)
+	r.w.size += int(n)
+
+	return n, err
+}
+
+// wrapBodyWriter returns the writer handlers write to through the BodyWriter. It implements
+// http.Flusher, http.Hijacker, http.Pusher and io.ReaderFrom only when the writer wrapped by the
+// BodyWriter does, so checking for them keeps telling what the connection supports.
+func wrapBodyWriter(w *BodyWriter) http.ResponseWriter {
+	base := bodyResponseWriter{w: w}
+	flusher, isFlusher := w.ResponseWriter.(http.Flusher)
+	hijacker, isHijacker := w.ResponseWriter.(http.Hijacker)
+	pusher, isPusher := w.ResponseWriter.(http.Pusher)
+	var readerFrom io.ReaderFrom
+	if wrapped, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		readerFrom = bodyReaderFrom{w: w, readerFrom: wrapped}
+	}
+
+	var supports int
+	if isFlusher {
+		supports |= 1
+	}
+	if isHijacker {
+		supports |= 2
+	}
+	if isPusher {
+		supports |= 4
+	}
+	if readerFrom != nil {
+		supports |= 8
+	}
+
+	switch supports {
+	case 1:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+		}{base, flusher}
+	case 2:
+		return struct {
+			bodyResponseWriter
+			http.Hijacker
+		}{base, hijacker}
+	case 3:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Hijacker
+		}{base, flusher, hijacker}
+	case 4:
+		return struct {
+			bodyResponseWriter
+			http.Pusher
+		}{base, pusher}
+	case 5:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Pusher
+		}{base, flusher, pusher}
+	case 6:
+		return struct {
+			bodyResponseWriter
+			http.Hijacker
+			http.Pusher
+		}{base, hijacker, pusher}
+	case 7:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{base, flusher, hijacker, pusher}
+	case 8:
+		return struct {
+			bodyResponseWriter
+			io.ReaderFrom
+		}{base, readerFrom}
+	case 9:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			io.ReaderFrom
+		}{base, flusher, readerFrom}
+	case 10:
+		return struct {
+			bodyResponseWriter
+			http.Hijacker
+			io.ReaderFrom
+		}{base, hijacker, readerFrom}
+	case 11:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{base, flusher, hijacker, readerFrom}
+	case 12:
+		return struct {
+			bodyResponseWriter
+			http.Pusher
+			io.ReaderFrom
+		}{base, pusher, readerFrom}
+	case 13:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Pusher
+			io.ReaderFrom
+		}{base, flusher, pusher, readerFrom}
+	case 14:
+		return struct {
+			bodyResponseWriter
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{base, hijacker, pusher, readerFrom}
+	case 15:
+		return struct {
+			bodyResponseWriter
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{base, flusher, hijacker, pusher, readerFrom}
+	}
+
+	return base
+}
@@ -0,0 +1,111 @@
+package chi
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fullResponseWriter struct {
+	*httptest.ResponseRecorder
+	readFrom      string
+	writeDeadline time.Time
+}
+
+func (w *fullResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, nil
+}
+
+func (w *fullResponseWriter) Push(target string, opts *http.PushOptions) error {
+	return nil
+}
+
+func (w *fullResponseWriter) ReadFrom(src io.Reader) (int64, error) {
+	body, err := io.ReadAll(src)
+	w.readFrom = string(body)
+
+	return int64(len(body)), err
+}
+
+func (w *fullResponseWriter) SetWriteDeadline(deadline time.Time) error {
+	w.writeDeadline = deadline
+
+	return nil
+}
+
+func TestWrapBodyWriter(t *testing.T) {
+	t.Run("exposes the interfaces of the wrapped writer only", func(t *testing.T) {
+		w := wrapBodyWriter(&BodyWriter{ResponseWriter: httptest.NewRecorder(), body: new(bytes.Buffer)})
+
+		_, isFlusher := w.(http.Flusher)
+		_, isHijacker := w.(http.Hijacker)
+		_, isPusher := w.(http.Pusher)
+		_, isReaderFrom := w.(io.ReaderFrom)
+		assert.True(t, isFlusher)
+		assert.False(t, isHijacker)
+		assert.False(t, isPusher)
+		assert.False(t, isReaderFrom)
+	})
+
+	t.Run("body writer doesn't claim optional interfaces", func(t *testing.T) {
+		var w http.ResponseWriter = &BodyWriter{ResponseWriter: httptest.NewRecorder()}
+
+		_, isFlusher := w.(http.Flusher)
+		_, isHijacker := w.(http.Hijacker)
+		assert.False(t, isFlusher)
+		assert.False(t, isHijacker)
+	})
+
+	t.Run("exposes all the interfaces", func(t *testing.T) {
+		w := wrapBodyWriter(&BodyWriter{ResponseWriter: &fullResponseWriter{ResponseRecorder: httptest.NewRecorder()}, body: new(bytes.Buffer)})
+
+		_, isFlusher := w.(http.Flusher)
+		_, isHijacker := w.(http.Hijacker)
+		_, isPusher := w.(http.Pusher)
+		_, isReaderFrom := w.(io.ReaderFrom)
+		assert.True(t, isFlusher)
+		assert.True(t, isHijacker)
+		assert.True(t, isPusher)
+		assert.True(t, isReaderFrom)
+	})
+
+	t.Run("read from captures the body", func(t *testing.T) {
+		recorder := &fullResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		bodyWriter := &BodyWriter{ResponseWriter: recorder, body: new(bytes.Buffer)}
+		w := wrapBodyWriter(bodyWriter)
+
+		n, err := io.Copy(w, struct{ io.Reader }{strings.NewReader("goravel")})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(7), n)
+		assert.Equal(t, "goravel", bodyWriter.Body().String())
+		assert.Equal(t, "goravel", recorder.Body.String())
+		assert.Equal(t, "", recorder.readFrom)
+	})
+
+	t.Run("read from uses the wrapped writer without capture", func(t *testing.T) {
+		recorder := &fullResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		w := wrapBodyWriter(&BodyWriter{ResponseWriter: recorder})
+
+		_, err := io.Copy(w, struct{ io.Reader }{strings.NewReader("goravel")})
+		assert.Nil(t, err)
+		assert.Equal(t, "goravel", recorder.readFrom)
+	})
+
+	t.Run("response controller", func(t *testing.T) {
+		recorder := &fullResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		w := wrapBodyWriter(&BodyWriter{ResponseWriter: recorder, body: new(bytes.Buffer)})
+
+		deadline := time.Now().Add(time.Minute)
+		assert.Nil(t, http.NewResponseController(w).SetWriteDeadline(deadline))
+		assert.Equal(t, deadline, recorder.writeDeadline)
+		assert.ErrorIs(t, http.NewResponseController(w).EnableFullDuplex(), http.ErrNotSupported)
+	})
+}
@@ -0,0 +1,86 @@
+package chi
+
+import (
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type BodyCaptureOptions struct {
+	// Disabled stops capturing response bodies, Origin().Body() stays empty.
+	Disabled bool
+	// MaxBytes is the maximum number of bytes captured per response, the rest of the body is still
+	// written and BodyWriter.Truncated reports it. 0 means no limit.
+	MaxBytes int
+	// ContentTypes restricts capture to responses with one of the content types, such as
+	// "application/json" or "text/*". Responses without a Content-Type when their body starts being
+	// written aren't captured then. Empty captures every content type.
+	ContentTypes []string
+	// Routes restricts capture to the routes with one of the patterns, such as "/users/{id}" or
+	// "/api/*" for the routes under /api. Patterns are written without the host of Domain and the
+	// constraints of Where. Empty captures every route.
+	Routes []string
+}
+
+// BodyCapture configures how ResponseMiddleware captures response bodies for
+// Response().Origin().Body(), which are captured entirely by default. Size and Status report the
+// whole response whatever is captured.
+func (r *Route) BodyCapture(options BodyCaptureOptions) {
+	r.instance.bodyCapture = options
+}
+
+func (r BodyCaptureOptions) allowsRoute(rctx *chi.Context) bool {
+	if r.Disabled {
+		return false
+	}
+	if len(r.Routes) == 0 {
+		return true
+	}
+	if rctx == nil {
+		return false
+	}
+
+	pattern := plainRoutePattern(rctx.RoutePattern())
+	for _, route := range r.Routes {
+		if prefix, ok := strings.CutSuffix(route, "*"); ok {
+			if strings.HasPrefix(pattern, prefix) {
+				return true
+			}
+		} else if route == pattern {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r BodyCaptureOptions) allowsContentType(contentType string) bool {
+	return len(r.ContentTypes) == 0 || matchContentType(contentType, r.ContentTypes)
+}
+
+// plainRoutePattern removes the host of the routes of Domain and the inline patterns of parameters,
+// such as the ones of Where, from a route pattern, e.g. /users/{id:(?:[0-9]+)} becomes /users/{id}.
+func plainRoutePattern(pattern string) string {
+	_, pattern = splitDomainRoute(pattern)
+
+	var builder strings.Builder
+	depth, inline := 0, false
+	for _, char := range pattern {
+		switch {
+		case char == '{':
+			depth++
+		case char == '}':
+			depth--
+			if depth == 0 {
+				inline = false
+			}
+		case char == ':' && depth == 1:
+			inline = true
+		}
+		if !inline {
+			builder.WriteRune(char)
+		}
+	}
+
+	return builder.String()
+}
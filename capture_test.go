@@ -0,0 +1,134 @@
+package chi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyCapture(t *testing.T) {
+	tests := []struct {
+		name            string
+		options         BodyCaptureOptions
+		url             string
+		contentType     string
+		expectBody      string
+		expectTruncated bool
+	}{
+		{
+			name:       "default",
+			url:        "/users/1",
+			expectBody: "goravel framework",
+		},
+		{
+			name:    "disabled",
+			options: BodyCaptureOptions{Disabled: true},
+			url:     "/users/1",
+		},
+		{
+			name:            "max bytes",
+			options:         BodyCaptureOptions{MaxBytes: 10},
+			url:             "/users/1",
+			expectBody:      "goravel fr",
+			expectTruncated: true,
+		},
+		{
+			name:       "max bytes not reached",
+			options:    BodyCaptureOptions{MaxBytes: 17},
+			url:        "/users/1",
+			expectBody: "goravel framework",
+		},
+		{
+			name:        "allowed content type",
+			options:     BodyCaptureOptions{ContentTypes: []string{"application/json", "text/*"}},
+			url:         "/users/1",
+			contentType: "text/plain; charset=utf-8",
+			expectBody:  "goravel framework",
+		},
+		{
+			name:        "other content type",
+			options:     BodyCaptureOptions{ContentTypes: []string{"application/json"}},
+			url:         "/users/1",
+			contentType: "application/octet-stream",
+		},
+		{
+			name:    "without content type",
+			options: BodyCaptureOptions{ContentTypes: []string{"application/json"}},
+			url:     "/users/1",
+		},
+		{
+			name:       "allowed route",
+			options:    BodyCaptureOptions{Routes: []string{"/users/{id}"}},
+			url:        "/users/1",
+			expectBody: "goravel framework",
+		},
+		{
+			name:       "allowed route prefix",
+			options:    BodyCaptureOptions{Routes: []string{"/api/*"}},
+			url:        "/api/users",
+			expectBody: "goravel framework",
+		},
+		{
+			name:       "allowed domain route",
+			options:    BodyCaptureOptions{Routes: []string{"/tenants/{id}"}},
+			url:        "http://acme.example.com/tenants/1",
+			expectBody: "goravel framework",
+		},
+		{
+			name:       "allowed constrained route",
+			options:    BodyCaptureOptions{Routes: []string{"/currencies/{code}"}},
+			url:        "/currencies/USD",
+			expectBody: "goravel framework",
+		},
+		{
+			name:    "other route",
+			options: BodyCaptureOptions{Routes: []string{"/api/*"}},
+			url:     "/users/1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			route.BodyCapture(test.options)
+
+			var origin *BodyWriter
+			handler := func(ctx contractshttp.Context) contractshttp.Response {
+				origin = ctx.Value("responseOrigin").(*BodyWriter)
+				if test.contentType != "" {
+					ctx.Response().Header("Content-Type", test.contentType)
+				}
+				w := ctx.Response().Writer()
+				_, _ = w.Write([]byte("goravel "))
+				_, _ = io.Copy(w, struct{ io.Reader }{strings.NewReader("framework")})
+
+				return nil
+			}
+			route.Get("/users/{id}", handler)
+			route.Get("/api/users", handler)
+			route.Domain("{tenant}.example.com").Get("/tenants/{id}", handler)
+			route.Router.(*Group).Where("code", "[A-Z]{3}").Get("/currencies/{code}", handler)
+
+			w := httptest.NewRecorder()
+			route.ServeHTTP(w, httptest.NewRequest("GET", test.url, nil))
+
+			assert.Equal(t, "goravel framework", w.Body.String())
+			assert.Equal(t, test.expectBody, origin.Body().String())
+			assert.Equal(t, test.expectTruncated, origin.Truncated())
+			assert.Equal(t, 17, origin.Size())
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
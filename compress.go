@@ -0,0 +1,373 @@
+package chi
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	EncodingBrotli = "br"
+	EncodingGzip   = "gzip"
+	EncodingZstd   = "zstd"
+)
+
+var defaultCompressContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/*+json",
+	"application/javascript",
+	"application/xml",
+	"application/*+xml",
+	"application/x-ndjson",
+	"image/svg+xml",
+}
+
+type CompressOptions struct {
+	// Encodings lists the enabled encodings in order of preference, default is br, zstd and gzip.
+	Encodings []string
+	// MinSize is the minimum body size in bytes to compress, default is 1024.
+	MinSize int
+	// ContentTypes lists the media types that may be compressed, "text/*" and "application/*+json"
+	// style wildcards are supported. Default covers text, JSON, JavaScript, XML and SVG.
+	ContentTypes []string
+}
+
+type compressEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var compressEncoderPools = map[string]*sync.Pool{
+	EncodingBrotli: {New: func() any {
+		return brotli.NewWriter(nil)
+	}},
+	EncodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	EncodingZstd: {New: func() any {
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return encoder
+	}},
+}
+
+// Compress compresses response bodies with the encoding negotiated from the Accept-Encoding header.
+// HEAD requests are answered with the headers of GET without encoding the body.
+func Compress(options ...CompressOptions) contractshttp.Middleware {
+	option := CompressOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+	if len(option.Encodings) == 0 {
+		option.Encodings = []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+	}
+	if option.MinSize <= 0 {
+		option.MinSize = 1024
+	}
+	if len(option.ContentTypes) == 0 {
+		option.ContentTypes = defaultCompressContentTypes
+	}
+
+	return func(ctx contractshttp.Context) {
+		request := ctx.Request().Origin()
+		if request.Header.Get("Upgrade") != "" {
+			ctx.Request().Next()
+			return
+		}
+
+		writer := ctx.Response().Writer()
+		if !headerContainsToken(writer.Header(), "Vary", "Accept-Encoding") {
+			writer.Header().Add("Vary", "Accept-Encoding")
+		}
+
+		encoding := negotiateEncoding(request.Header.Get("Accept-Encoding"), option.Encodings)
+		if encoding == "" {
+			ctx.Request().Next()
+			return
+		}
+
+		cw := &compressWriter{encoding: encoding, options: &option, head: request.Method == http.MethodHead}
+		switch ctx := ctx.(type) {
+		case *Context:
+			cw.ResponseWriter = ctx.w
+			ctx.w = cw
+		}
+
+		ctx.Request().Next()
+		_ = cw.Close()
+	}
+}
+
+// WithoutCompression disables response compression for the routes it is applied to, the writer of
+// Compress is found through the writers wrapping it, such as the one of ETag.
+func WithoutCompression() contractshttp.Middleware {
+	return func(ctx contractshttp.Context) {
+		writer := ctx.Response().Writer()
+		for writer != nil {
+			if cw, ok := writer.(*compressWriter); ok {
+				cw.disabled = true
+				break
+			}
+			unwrapper, ok := writer.(interface{ Unwrap() http.ResponseWriter })
+			if !ok {
+				break
+			}
+			writer = unwrapper.Unwrap()
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+type compressWriter struct {
+	http.ResponseWriter
+	options  *CompressOptions
+	encoding string
+	encoder  compressEncoder
+	buffer   []byte
+	status   int
+	decided  bool
+	disabled bool
+	// head answers HEAD requests with the headers of GET, the body isn't sent so it isn't encoded.
+	head    bool
+	discard bool
+}
+
+func (w *compressWriter) WriteHeader(code int) {
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status != 0 {
+		return
+	}
+
+	w.status = code
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if w.decided {
+		if w.discard {
+			return len(b), nil
+		}
+		if w.encoder != nil {
+			return w.encoder.Write(b)
+		}
+
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) >= w.options.MinSize {
+		if err := w.decide(false); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends the buffered data to the client. Once a response has been flushed it is treated as a
+// stream, so it is compressed regardless of MinSize when the content type allows it.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		if err := w.decide(true); err != nil {
+			return
+		}
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if w.status == 0 {
+			return nil
+		}
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	w.encoder.Reset(nil)
+	compressEncoderPools[w.encoding].Put(w.encoder)
+	w.encoder = nil
+
+	return err
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *compressWriter) decide(streaming bool) error {
+	w.decided = true
+
+	header := w.Header()
+	if header.Get("Content-Type") == "" && len(w.buffer) > 0 {
+		header.Set("Content-Type", http.DetectContentType(w.buffer))
+	}
+
+	if w.shouldCompress(streaming) {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		// The encoded body differs byte for byte, so a strong validator becomes a weak one.
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		if w.head {
+			w.discard = true
+		} else {
+			w.encoder = compressEncoderPools[w.encoding].Get().(compressEncoder)
+			w.encoder.Reset(w.ResponseWriter)
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 || w.discard {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buffer)
+		return err
+	}
+
+	_, err := w.ResponseWriter.Write(buffer)
+	return err
+}
+
+func (w *compressWriter) shouldCompress(streaming bool) bool {
+	if w.disabled {
+		return false
+	}
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified || w.status == http.StatusPartialContent {
+		return false
+	}
+	if !streaming && len(w.buffer) < w.options.MinSize {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" || headerContainsToken(header, "Cache-Control", "no-transform") {
+		return false
+	}
+
+	return matchContentType(header.Get("Content-Type"), w.options.ContentTypes)
+}
+
+// negotiateEncoding picks the encoding with the highest q-value in the Accept-Encoding header, ties
+// are broken by the order of the supported encodings.
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, quality := parseQuality(part)
+		if name == "" {
+			continue
+		}
+		if name == "*" {
+			wildcard = quality
+			continue
+		}
+		qualities[name] = quality
+	}
+
+	best, bestQuality := "", 0.0
+	for _, encoding := range supported {
+		quality, exist := qualities[encoding]
+		if !exist {
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+
+	return best
+}
+
+// parseQuality splits an element of a header such as Accept or Accept-Encoding into its lower-cased
+// value and q-value. A missing or invalid q-value counts as 1.
+func parseQuality(part string) (string, float64) {
+	value, params, _ := strings.Cut(part, ";")
+	value = strings.ToLower(strings.TrimSpace(value))
+	quality := 1.0
+	for _, param := range strings.Split(params, ";") {
+		key, val, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		if q, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+			quality = q
+		}
+	}
+
+	return value, quality
+}
+
+func matchContentType(contentType string, patterns []string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if pattern == mediaType || pattern == "*/*" {
+			return true
+		}
+		if prefix, suffix, found := strings.Cut(pattern, "*"); found &&
+			strings.HasPrefix(mediaType, prefix) && strings.HasSuffix(mediaType, suffix) &&
+			len(mediaType) > len(prefix)+len(suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func headerContainsToken(header http.Header, key, token string) bool {
+	for _, value := range header.Values(key) {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
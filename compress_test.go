@@ -0,0 +1,249 @@
+package chi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	var (
+		mockConfig *configmocks.Config
+		route      *Route
+	)
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	}
+
+	large := strings.Repeat("Goravel ", 256)
+	tests := []struct {
+		name           string
+		setup          func()
+		acceptEncoding string
+		expectEncoding string
+		expectBody     string
+	}{
+		{
+			name: "gzip",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, large)
+				})
+			},
+			acceptEncoding: "gzip",
+			expectEncoding: EncodingGzip,
+			expectBody:     large,
+		},
+		{
+			name: "brotli is preferred",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, large)
+				})
+			},
+			acceptEncoding: "gzip, deflate, br, zstd",
+			expectEncoding: EncodingBrotli,
+			expectBody:     large,
+		},
+		{
+			name: "q-values are respected",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Json(http.StatusOK, contractshttp.Json{"data": large})
+				})
+			},
+			acceptEncoding: "br;q=0.5, zstd;q=0.8, gzip;q=0",
+			expectEncoding: EncodingZstd,
+			expectBody:     "{\"data\":\"" + large + "\"}\n",
+		},
+		{
+			name: "body smaller than min size",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "Goravel")
+				})
+			},
+			acceptEncoding: "gzip",
+			expectBody:     "Goravel",
+		},
+		{
+			name: "content type not allowed",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Data(http.StatusOK, "image/png", []byte(large))
+				})
+			},
+			acceptEncoding: "gzip",
+			expectBody:     large,
+		},
+		{
+			name: "custom content types and min size",
+			setup: func() {
+				route.Middleware(Compress(CompressOptions{
+					MinSize:      1,
+					ContentTypes: []string{"image/*"},
+				})).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Data(http.StatusOK, "image/png", []byte("Goravel"))
+				})
+			},
+			acceptEncoding: "gzip",
+			expectEncoding: EncodingGzip,
+			expectBody:     "Goravel",
+		},
+		{
+			name: "disabled for route",
+			setup: func() {
+				route.Middleware(Compress(), WithoutCompression()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, large)
+				})
+			},
+			acceptEncoding: "gzip",
+			expectBody:     large,
+		},
+		{
+			name: "disabled for route behind another writer",
+			setup: func() {
+				route.Middleware(Compress(), ETag(), WithoutCompression()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, large)
+				})
+			},
+			acceptEncoding: "gzip",
+			expectBody:     large,
+		},
+		{
+			name: "client does not accept encoding",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, large)
+				})
+			},
+			expectBody: large,
+		},
+		{
+			name: "stream is compressed after flushing",
+			setup: func() {
+				route.Middleware(Compress()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					ctx.Response().Header("Content-Type", "text/plain")
+					ctx.Response().Writer().WriteHeader(http.StatusOK)
+					_, _ = ctx.Response().Writer().Write([]byte("Goravel"))
+					ctx.Response().Flush()
+
+					return nil
+				})
+			},
+			acceptEncoding: "gzip",
+			expectEncoding: EncodingGzip,
+			expectBody:     "Goravel",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			var err error
+			route, err = NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			test.setup()
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "/", nil)
+			assert.Nil(t, err)
+			if test.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", test.acceptEncoding)
+			}
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, test.expectEncoding, w.Header().Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Equal(t, test.expectBody, decompress(t, test.expectEncoding, w.Body.Bytes()))
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCompress_Head(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+
+	route.Middleware(Compress()).Get("/large", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().String(http.StatusOK, strings.Repeat("Goravel ", 256))
+	})
+	route.Middleware(Compress()).Get("/small", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().String(http.StatusOK, "Goravel")
+	})
+
+	request := func(method, url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		route.ServeHTTP(w, req)
+
+		return w
+	}
+
+	for url, expectEncoding := range map[string]string{"/large": EncodingGzip, "/small": ""} {
+		get, head := request(http.MethodGet, url), request(http.MethodHead, url)
+
+		assert.Equal(t, http.StatusOK, head.Code, url)
+		assert.Equal(t, expectEncoding, head.Header().Get("Content-Encoding"), url)
+		assert.Equal(t, "Accept-Encoding", head.Header().Get("Vary"), url)
+		for _, key := range []string{"Content-Encoding", "Content-Length", "Content-Type", "Vary"} {
+			assert.Equal(t, get.Header().Get(key), head.Header().Get(key), url+" "+key)
+		}
+	}
+	assert.Empty(t, request(http.MethodHead, "/large").Body.String())
+	mockConfig.AssertExpectations(t)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	supported := []string{EncodingBrotli, EncodingZstd, EncodingGzip}
+
+	assert.Equal(t, "", negotiateEncoding("", supported))
+	assert.Equal(t, "", negotiateEncoding("deflate", supported))
+	assert.Equal(t, EncodingGzip, negotiateEncoding("GZIP", supported))
+	assert.Equal(t, EncodingBrotli, negotiateEncoding("*", supported))
+	assert.Equal(t, EncodingZstd, negotiateEncoding("*;q=0.1, zstd", supported))
+	assert.Equal(t, EncodingGzip, negotiateEncoding("*;q=0.5, br;q=0, zstd;q=0, gzip", supported))
+	assert.Equal(t, "", negotiateEncoding("*;q=0", supported))
+}
+
+func decompress(t *testing.T, encoding string, body []byte) string {
+	var (
+		reader io.Reader
+		err    error
+	)
+	switch encoding {
+	case EncodingGzip:
+		reader, err = gzip.NewReader(bytes.NewReader(body))
+	case EncodingBrotli:
+		reader = brotli.NewReader(bytes.NewReader(body))
+	case EncodingZstd:
+		reader, err = zstd.NewReader(bytes.NewReader(body))
+	default:
+		return string(body)
+	}
+	assert.Nil(t, err)
+
+	data, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+
+	return string(data)
+}
@@ -20,6 +20,9 @@ type Context struct {
 	instance *Instance
 	request  http.ContextRequest
 	response http.ContextResponse
+	next     func()
+	aborted  bool
+	models   map[string]any
 }
 
 func NewContext(instance *Instance, w nethttp.ResponseWriter, r *nethttp.Request) http.Context {
@@ -69,3 +72,15 @@ func (c *Context) Value(key any) any {
 func (c *Context) Instance() *Instance {
 	return c.instance
 }
+
+// callNext runs the rest of the handler chain, at most once per middleware and never after the
+// request was aborted.
+func (c *Context) callNext() {
+	if c.next == nil || c.aborted {
+		return
+	}
+
+	next := c.next
+	c.next = nil
+	next()
+}
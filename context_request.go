@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"net/http"
 	"reflect"
@@ -14,6 +15,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-rat/chix"
 	"github.com/go-rat/chix/binder"
+	"github.com/google/uuid"
 	"github.com/gookit/validate"
 	contractsfilesystem "github.com/goravel/framework/contracts/filesystem"
 	contractshttp "github.com/goravel/framework/contracts/http"
@@ -45,10 +47,17 @@ func NewContextRequest(ctx *Context, log log.Log, validation contractsvalidate.V
 }
 
 func (r *ContextRequest) AbortWithStatus(code int) {
+	r.ctx.aborted = true
+	if code >= http.StatusBadRequest {
+		abortWithStatus(r.ctx.instance, r.ctx.w, r.ctx.r, code)
+		return
+	}
+
 	r.render.Status(code)
 }
 
 func (r *ContextRequest) AbortWithStatusJson(code int, jsonObj any) {
+	r.ctx.aborted = true
 	r.render.Status(code)
 	r.render.JSON(jsonObj)
 }
@@ -82,8 +91,22 @@ func (r *ContextRequest) All() map[string]any {
 	return dataMap
 }
 
+// Bind binds the request body to obj, bodies other than forms are decoded by the body decoder
+// registered for their media type.
 func (r *ContextRequest) Bind(obj any) error {
-	return r.bind.Body(obj)
+	decoder, exist := getBodyDecoder(r.ctx.r.Header.Get("Content-Type"))
+	if !exist || r.ctx.r.Body == nil {
+		return r.bind.Body(obj)
+	}
+
+	body, err := io.ReadAll(r.ctx.r.Body)
+	_ = r.ctx.r.Body.Close()
+	if err != nil {
+		return err
+	}
+	r.ctx.r.Body = io.NopCloser(bytes.NewBuffer(body))
+
+	return decoder.Decode(body, obj)
 }
 
 func (r *ContextRequest) BindQuery(obj any) error {
@@ -197,7 +220,7 @@ func (r *ContextRequest) Method() string {
 }
 
 func (r *ContextRequest) Next() {
-	// TODO how to implement this?
+	r.ctx.callNext()
 }
 
 func (r *ContextRequest) Query(key string, defaultValue ...string) string {
@@ -408,6 +431,59 @@ func (r *ContextRequest) RouteInt64(key string) int64 {
 	return cast.ToInt64(val)
 }
 
+// RouteIntE is like RouteInt, but returns an error when the parameter is missing or isn't a decimal
+// integer instead of 0.
+func (r *ContextRequest) RouteIntE(key string) (int, error) {
+	val, err := r.routeParam(key)
+	if err != nil {
+		return 0, err
+	}
+
+	number, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("route parameter %s is not an integer: %w", key, err)
+	}
+
+	return number, nil
+}
+
+// RouteInt64E is like RouteInt64, but returns an error when the parameter is missing or isn't a
+// decimal integer instead of 0.
+func (r *ContextRequest) RouteInt64E(key string) (int64, error) {
+	val, err := r.routeParam(key)
+	if err != nil {
+		return 0, err
+	}
+
+	number, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("route parameter %s is not an integer: %w", key, err)
+	}
+
+	return number, nil
+}
+
+// RouteUUID parses the route parameter as a UUID.
+func (r *ContextRequest) RouteUUID(key string) (uuid.UUID, error) {
+	val, err := r.routeParam(key)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id, err := uuid.Parse(val)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("route parameter %s is not a uuid: %w", key, err)
+	}
+
+	return id, nil
+}
+
+// RouteModel returns the value a route parameter was resolved to by the binder registered with
+// Route.Bind or Route.Model, nil if the parameter isn't bound.
+func (r *ContextRequest) RouteModel(key string) any {
+	return r.ctx.models[key]
+}
+
 func (r *ContextRequest) Session() contractsession.Session {
 	s, ok := r.ctx.Value("session").(contractsession.Session)
 	if !ok {
@@ -434,6 +510,11 @@ func (r *ContextRequest) Validate(rules map[string]string, options ...contractsv
 	options = append(options, validation.Rules(rules), validation.CustomRules(r.validation.Rules()), validation.CustomFilters(r.validation.Filters()))
 
 	dataFace, err := validate.FromRequest(r.ctx.Request().Origin())
+	if errors.Is(err, validate.ErrEmptyData) && r.httpBody != nil {
+		// gookit/validate only reads JSON and form bodies, other media types are validated against
+		// the body decoded by their body decoder.
+		dataFace, err = validate.FromMap(maps.Clone(r.httpBody)), nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -482,6 +563,19 @@ func (r *ContextRequest) ValidateRequest(request contractshttp.FormRequest) (con
 	return validator.Errors(), nil
 }
 
+func (r *ContextRequest) routeParam(key string) (string, error) {
+	chiCtx := chi.RouteContext(r.ctx.r.Context())
+	if chiCtx != nil {
+		for k := len(chiCtx.URLParams.Keys) - 1; k >= 0; k-- {
+			if chiCtx.URLParams.Keys[k] == key {
+				return chiCtx.URLParams.Values[k], nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("route parameter %s doesn't exist", key)
+}
+
 func (r *ContextRequest) getValueFromHttpBody(key string) any {
 	if r.httpBody == nil {
 		return nil
@@ -519,22 +613,25 @@ func getHttpBody(ctx *Context) (map[string]any, error) {
 	if ctx.r == nil || ctx.r.Body == nil || ctx.r.ContentLength == 0 {
 		return nil, nil
 	}
+	// Encoded bodies are parsed once Decompress has decoded them.
+	if encoding := strings.TrimSpace(ctx.r.Header.Get("Content-Encoding")); encoding != "" && !strings.EqualFold(encoding, "identity") {
+		return nil, nil
+	}
 
 	contentType := strings.ToLower(ctx.r.Header.Get("Content-Type"))
 	contentType = binder.FilterFlags(contentType)
 	data := make(map[string]any)
-	if contentType == "application/json" {
+	if decoder, exist := getBodyDecoder(contentType); exist {
 		bodyBytes, err := io.ReadAll(ctx.r.Body)
 		_ = ctx.r.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("retrieve json error: %v", err)
-		}
-
-		if err = json.Unmarshal(bodyBytes, &data); err != nil {
-			return nil, fmt.Errorf("decode json [%v] error: %v", string(bodyBytes), err)
+			return nil, fmt.Errorf("retrieve %s error: %v", contentType, err)
 		}
 
 		ctx.r.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+		if err = decoder.Decode(bodyBytes, &data); err != nil {
+			return nil, fmt.Errorf("decode %s [%v] error: %v", contentType, string(bodyBytes), err)
+		}
 	}
 
 	if contentType == "multipart/form-data" {
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/fxamacker/cbor/v2"
 	contractshttp "github.com/goravel/framework/contracts/http"
 	frameworkfilesystem "github.com/goravel/framework/filesystem"
 	foundationjson "github.com/goravel/framework/foundation/json"
@@ -22,9 +23,11 @@ import (
 	"github.com/goravel/framework/session"
 	"github.com/goravel/framework/support/json"
 	"github.com/goravel/framework/validation"
+	"github.com/spf13/cast"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
 type ContextRequestSuite struct {
@@ -279,6 +282,82 @@ func (s *ContextRequestSuite) TestBind_Json() {
 	s.Equal(http.StatusOK, code)
 }
 
+func (s *ContextRequestSuite) TestBind_BodyDecoders() {
+	type User struct {
+		Name string `json:"name" xml:"name" yaml:"name" msgpack:"name" cbor:"name"`
+		Age  int    `json:"age" xml:"age" yaml:"age" msgpack:"age" cbor:"age"`
+	}
+	user := User{Name: "Goravel", Age: 18}
+	msgpackBody, err := msgpack.Marshal(user)
+	s.Require().Nil(err)
+	cborBody, err := cbor.Marshal(user)
+	s.Require().Nil(err)
+
+	RegisterBodyDecoder("application/x-goravel", BodyDecoderFunc(func(body []byte, out any) error {
+		name, age, _ := strings.Cut(string(body), ":")
+		switch out := out.(type) {
+		case *map[string]any:
+			(*out)["name"], (*out)["age"] = name, age
+		case *User:
+			out.Name, out.Age = name, cast.ToInt(age)
+		}
+		return nil
+	}))
+	s.T().Cleanup(func() {
+		bodyDecodersLock.Lock()
+		defer bodyDecodersLock.Unlock()
+
+		delete(bodyDecoders, "application/x-goravel")
+	})
+
+	s.route.Post("/bind/decoders", func(ctx contractshttp.Context) contractshttp.Response {
+		var data User
+		if err := ctx.Request().Bind(&data); err != nil {
+			return ctx.Response().String(http.StatusBadRequest, err.Error())
+		}
+		validator, err := ctx.Request().Validate(map[string]string{
+			"name": "required",
+			"age":  "required",
+		})
+		if err != nil {
+			return ctx.Response().String(http.StatusBadRequest, err.Error())
+		}
+
+		return ctx.Response().Success().Json(contractshttp.Json{
+			"bind":  data,
+			"input": ctx.Request().Input("name"),
+			"int":   ctx.Request().InputInt("age"),
+			"fails": validator.Fails(),
+		})
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{name: "xml", contentType: "application/xml", body: []byte(`<user><name>Goravel</name><age>18</age></user>`)},
+		{name: "yaml", contentType: "application/yaml", body: []byte("name: Goravel\nage: 18\n")},
+		{name: "msgpack", contentType: "application/msgpack", body: msgpackBody},
+		{name: "cbor", contentType: "application/cbor", body: cborBody},
+		{name: "structured syntax suffix", contentType: "application/vnd.goravel+json; charset=utf-8", body: []byte(`{"name":"Goravel","age":18}`)},
+		{name: "registered decoder", contentType: "application/x-goravel", body: []byte("Goravel:18")},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			req, err := http.NewRequest("POST", "/bind/decoders", bytes.NewReader(test.body))
+			s.Require().Nil(err)
+			req.Header.Set("Content-Type", test.contentType)
+
+			code, body, _, _ := s.request(req)
+
+			s.Equal(http.StatusOK, code)
+			s.Equal("{\"bind\":{\"name\":\"Goravel\",\"age\":18},\"fails\":false,\"input\":\"Goravel\",\"int\":18}\n", body)
+		})
+	}
+}
+
 func (s *ContextRequestSuite) TestBind_Form() {
 	s.route.Post("/bind/form/{id}", func(ctx contractshttp.Context) contractshttp.Response {
 		id := ctx.Request().Input("id")
@@ -1132,6 +1211,46 @@ func (s *ContextRequestSuite) TestRoute() {
 	s.Equal(http.StatusOK, code)
 }
 
+func (s *ContextRequestSuite) TestRoute_WithError() {
+	s.route.Get("/route/{int}/{uuid}/{string}", func(ctx contractshttp.Context) contractshttp.Response {
+		request := ctx.Request().(*ContextRequest)
+		number, err := request.RouteIntE("int")
+		s.Nil(err)
+		number64, err := request.RouteInt64E("int")
+		s.Nil(err)
+		id, err := request.RouteUUID("uuid")
+		s.Nil(err)
+
+		_, intErr := request.RouteIntE("string")
+		_, int64Err := request.RouteInt64E("string")
+		_, uuidErr := request.RouteUUID("string")
+		_, missingErr := request.RouteIntE("missing")
+
+		return ctx.Response().Success().Json(contractshttp.Json{
+			"int":     number,
+			"int64":   number64,
+			"uuid":    id.String(),
+			"int_err": intErr.Error(),
+			"i64_err": int64Err.Error(),
+			"uid_err": uuidErr.Error(),
+			"missing": missingErr.Error(),
+		})
+	})
+
+	req, err := http.NewRequest("GET", "/route/2/2c0c9b7e-4b7f-4a8e-9b1a-3f1e6f1d2a3b/a", nil)
+	s.Require().Nil(err)
+
+	code, body, _, _ := s.request(req)
+
+	s.Equal(http.StatusOK, code)
+	s.Equal("{\"i64_err\":\"route parameter string is not an integer: strconv.ParseInt: parsing \\\"a\\\": invalid syntax\","+
+		"\"int\":2,\"int64\":2,"+
+		"\"int_err\":\"route parameter string is not an integer: strconv.Atoi: parsing \\\"a\\\": invalid syntax\","+
+		"\"missing\":\"route parameter missing doesn't exist\","+
+		"\"uid_err\":\"route parameter string is not a uuid: invalid UUID length: 1\","+
+		"\"uuid\":\"2c0c9b7e-4b7f-4a8e-9b1a-3f1e6f1d2a3b\"}\n", body)
+}
+
 func (s *ContextRequestSuite) TestSession() {
 	s.route.Get("/session", func(ctx contractshttp.Context) contractshttp.Response {
 		ctx.Request().SetSession(session.NewSession("goravel_session", nil, foundationjson.NewJson()))
@@ -2,8 +2,11 @@ package chi
 
 import (
 	"bytes"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-rat/chix"
 	contractshttp "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/support/carbon"
@@ -53,6 +56,13 @@ func (r *ContextResponse) Cookie(cookie contractshttp.Cookie) contractshttp.Cont
 	return r
 }
 
+// Content serves content with support for Range, If-Range and conditional requests. The name is
+// used to detect the Content-Type when it's not set, a zero modtime omits Last-Modified. Content
+// implementing io.Closer is closed once the response is rendered.
+func (r *ContextResponse) Content(name string, modtime time.Time, content io.ReadSeeker) contractshttp.Response {
+	return &ContentResponse{name, modtime, content, false, r.ctx.w, r.ctx.r}
+}
+
 func (r *ContextResponse) Data(code int, contentType string, data []byte) contractshttp.Response {
 	return &DataResponse{code, contentType, data, r.render}
 }
@@ -61,6 +71,11 @@ func (r *ContextResponse) Download(filepath, filename string) contractshttp.Resp
 	return &DownloadResponse{filename, filepath, r.render}
 }
 
+// DownloadContent is like Content, but prompts the client to save the content as filename.
+func (r *ContextResponse) DownloadContent(filename string, modtime time.Time, content io.ReadSeeker) contractshttp.Response {
+	return &ContentResponse{filename, modtime, content, true, r.ctx.w, r.ctx.r}
+}
+
 func (r *ContextResponse) File(filepath string) contractshttp.Response {
 	return &FileResponse{filepath, r.render}
 }
@@ -75,6 +90,20 @@ func (r *ContextResponse) Json(code int, obj any) contractshttp.Response {
 	return &JsonResponse{code, obj, r.render}
 }
 
+// Negotiate renders data as JSON, XML, YAML, MessagePack or plain text, whichever the Accept header
+// of the request prefers, and responds with 406 Not Acceptable when none of them is accepted. When a
+// view is given, browsers asking for text/html get the view rendered with data. Plain text is only
+// offered for strings, byte slices, errors and fmt.Stringer values, and XML for data xml.Marshal
+// can encode, which excludes maps.
+func (r *ContextResponse) Negotiate(code int, data any, view ...string) contractshttp.Response {
+	response := &NegotiateResponse{code: code, data: data, ctx: r.ctx, render: r.render}
+	if len(view) > 0 {
+		response.view = view[0]
+	}
+
+	return response
+}
+
 func (r *ContextResponse) NoContent(code ...int) contractshttp.Response {
 	if len(code) > 0 {
 		return &NoContentResponse{code[0], r.render}
@@ -83,10 +112,27 @@ func (r *ContextResponse) NoContent(code ...int) contractshttp.Response {
 	return &NoContentResponse{http.StatusNoContent, r.render}
 }
 
+// Error is the error returned by rendering the response of the handler, e.g. by the step function of
+// a stream, for middlewares once ctx.Request().Next() returns. The status code may have been sent
+// already then.
+func (r *ContextResponse) Error() error {
+	if holder, ok := r.ctx.Value(responseErrorKey{}).(*responseError); ok {
+		return holder.err
+	}
+
+	return nil
+}
+
 func (r *ContextResponse) Origin() contractshttp.ResponseOrigin {
 	return r.origin
 }
 
+// Problem renders an RFC 9457 problem details object as application/problem+json, use
+// NewValidationProblem for validation failures.
+func (r *ContextResponse) Problem(problem ProblemDetails) contractshttp.Response {
+	return &ProblemResponse{problem, r.ctx.w, r.ctx.r}
+}
+
 func (r *ContextResponse) Redirect(code int, location string) contractshttp.Response {
 	return &RedirectResponse{code, location, r.render}
 }
@@ -96,15 +142,40 @@ func (r *ContextResponse) String(code int, format string, values ...any) contrac
 }
 
 func (r *ContextResponse) Success() contractshttp.ResponseStatus {
-	return NewStatus(r.render, http.StatusOK)
+	return r.Status(http.StatusOK)
 }
 
 func (r *ContextResponse) Status(code int) contractshttp.ResponseStatus {
-	return NewStatus(r.render, code)
+	status := NewStatus(r.render, code)
+	status.ctx = r.ctx
+
+	return status
 }
 
 func (r *ContextResponse) Stream(code int, step func(w contractshttp.StreamWriter) error) contractshttp.Response {
-	return &StreamResponse{code, r.render, step}
+	return &StreamResponse{code: code, render: r.render, writer: step, ctx: r.ctx}
+}
+
+// SSE streams Server-Sent Events written by step with a 200 status code, the response ends when step
+// returns. Heartbeat comments keep the connection open meanwhile, step should return once
+// w.Context() is done since the client has disconnected.
+func (r *ContextResponse) SSE(step func(w *SSEWriter) error, options ...SSEOptions) contractshttp.Response {
+	response := &SSEResponse{step: step, w: r.ctx.w, r: r.ctx.r}
+	if len(options) > 0 {
+		response.options = options[0]
+	}
+
+	return response
+}
+
+// NDJSON streams the values as newline delimited JSON.
+func (r *ContextResponse) NDJSON(code int, values JSONValues) contractshttp.Response {
+	return &JSONStreamResponse{code: code, values: values, render: r.render, w: r.ctx.w, r: r.ctx.r}
+}
+
+// JSONArray streams the values as the items of a JSON array.
+func (r *ContextResponse) JSONArray(code int, values JSONValues) contractshttp.Response {
+	return &JSONStreamResponse{code: code, array: true, values: values, render: r.render, w: r.ctx.w, r: r.ctx.r}
 }
 
 func (r *ContextResponse) View() contractshttp.ResponseView {
@@ -127,10 +198,11 @@ func (r *ContextResponse) Flush() {
 type Status struct {
 	render *chix.Render
 	status int
+	ctx    *Context
 }
 
 func NewStatus(render *chix.Render, code int) *Status {
-	return &Status{render, code}
+	return &Status{render: render, status: code}
 }
 
 func (r *Status) Data(contentType string, data []byte) contractshttp.Response {
@@ -141,12 +213,32 @@ func (r *Status) Json(obj any) contractshttp.Response {
 	return &JsonResponse{r.status, obj, r.render}
 }
 
+// Negotiate is like ContextResponse.Negotiate with the status code of r.
+func (r *Status) Negotiate(data any, view ...string) contractshttp.Response {
+	response := &NegotiateResponse{code: r.status, data: data, ctx: r.ctx, render: r.render}
+	if len(view) > 0 {
+		response.view = view[0]
+	}
+
+	return response
+}
+
+// NDJSON is like ContextResponse.NDJSON with the status code of r.
+func (r *Status) NDJSON(values JSONValues) contractshttp.Response {
+	return &JSONStreamResponse{code: r.status, values: values, render: r.render, w: r.ctx.w, r: r.ctx.r}
+}
+
+// JSONArray is like ContextResponse.JSONArray with the status code of r.
+func (r *Status) JSONArray(values JSONValues) contractshttp.Response {
+	return &JSONStreamResponse{code: r.status, array: true, values: values, render: r.render, w: r.ctx.w, r: r.ctx.r}
+}
+
 func (r *Status) String(format string, values ...any) contractshttp.Response {
 	return &StringResponse{r.status, format, r.render, values}
 }
 
 func (r *Status) Stream(step func(w contractshttp.StreamWriter) error) contractshttp.Response {
-	return &StreamResponse{r.status, r.render, step}
+	return &StreamResponse{code: r.status, render: r.render, writer: step, ctx: r.ctx}
 }
 
 func ResponseMiddleware() contractshttp.Middleware {
@@ -155,7 +247,11 @@ func ResponseMiddleware() contractshttp.Middleware {
 		switch ctx := ctx.(type) {
 		case *Context:
 			blw.ResponseWriter = ctx.w
-			ctx.w = blw
+			if ctx.instance != nil {
+				blw.capture = ctx.instance.bodyCapture
+				blw.skip = !ctx.instance.bodyCapture.allowsRoute(chi.RouteContext(ctx.r.Context()))
+			}
+			ctx.w = wrapBodyWriter(blw)
 		}
 
 		ctx.WithValue("responseOrigin", blw)
@@ -165,22 +261,34 @@ func ResponseMiddleware() contractshttp.Middleware {
 
 type BodyWriter struct {
 	http.ResponseWriter
-	body   *bytes.Buffer
-	status int
+	body      *bytes.Buffer
+	status    int
+	size      int
+	capture   BodyCaptureOptions
+	skip      bool
+	checked   bool
+	truncated bool
 }
 
+// Size is the number of bytes written, including the ones that weren't captured.
 func (w *BodyWriter) Size() int {
-	return w.body.Len()
+	return w.size
 }
 
 func (w *BodyWriter) Status() int {
 	return w.status
 }
 
+// Truncated reports whether the body stopped being captured at BodyCaptureOptions.MaxBytes.
+func (w *BodyWriter) Truncated() bool {
+	return w.truncated
+}
+
 func (w *BodyWriter) Write(b []byte) (int, error) {
-	w.body.Write(b)
+	n, err := w.ResponseWriter.Write(b)
+	w.record(b[:n])
 
-	return w.ResponseWriter.Write(b)
+	return n, err
 }
 
 func (w *BodyWriter) WriteHeader(code int) {
@@ -189,9 +297,7 @@ func (w *BodyWriter) WriteHeader(code int) {
 }
 
 func (w *BodyWriter) WriteString(s string) (int, error) {
-	w.body.WriteString(s)
-
-	return w.ResponseWriter.Write([]byte(s))
+	return w.Write([]byte(s))
 }
 
 func (w *BodyWriter) Body() *bytes.Buffer {
@@ -201,3 +307,35 @@ func (w *BodyWriter) Body() *bytes.Buffer {
 func (w *BodyWriter) Header() http.Header {
 	return w.ResponseWriter.Header()
 }
+
+func (w *BodyWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// record counts the written bytes and captures the ones the options allow.
+func (w *BodyWriter) record(b []byte) {
+	w.size += len(b)
+	if !w.capturing() {
+		return
+	}
+
+	if w.capture.MaxBytes > 0 && w.body.Len()+len(b) > w.capture.MaxBytes {
+		b = b[:w.capture.MaxBytes-w.body.Len()]
+		w.truncated = true
+	}
+	w.body.Write(b)
+}
+
+// capturing reports whether the bytes written next are captured, the content type is checked once
+// the first bytes are written since handlers set it before.
+func (w *BodyWriter) capturing() bool {
+	if w.body == nil || w.skip || w.truncated {
+		return false
+	}
+	if !w.checked {
+		w.checked = true
+		w.skip = !w.capture.allowsContentType(w.Header().Get("Content-Type"))
+	}
+
+	return !w.skip
+}
@@ -0,0 +1,165 @@
+package chi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-rat/chix/binder"
+	"github.com/goravel/framework/support/json"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+const MIMEApplicationCBOR = "application/cbor"
+
+// BodyDecoder decodes request bodies of the media types it's registered for. out is either a
+// *map[string]any, which feeds Input, All and Validate, or the pointer passed to Bind.
+type BodyDecoder interface {
+	Decode(body []byte, out any) error
+}
+
+// BodyDecoderFunc adapts a function to a BodyDecoder.
+type BodyDecoderFunc func(body []byte, out any) error
+
+func (f BodyDecoderFunc) Decode(body []byte, out any) error {
+	return f(body, out)
+}
+
+var (
+	bodyDecodersLock sync.RWMutex
+	bodyDecoders     = map[string]BodyDecoder{
+		"application/json":        BodyDecoderFunc(json.Unmarshal),
+		"application/xml":         BodyDecoderFunc(decodeXML),
+		"text/xml":                BodyDecoderFunc(decodeXML),
+		MIMEApplicationYAML:       BodyDecoderFunc(yaml.Unmarshal),
+		"application/x-yaml":      BodyDecoderFunc(yaml.Unmarshal),
+		"text/yaml":               BodyDecoderFunc(yaml.Unmarshal),
+		MIMEApplicationMsgPack:    BodyDecoderFunc(msgpack.Unmarshal),
+		"application/x-msgpack":   BodyDecoderFunc(msgpack.Unmarshal),
+		"application/vnd.msgpack": BodyDecoderFunc(msgpack.Unmarshal),
+		MIMEApplicationCBOR:       BodyDecoderFunc(decodeCBOR),
+	}
+)
+
+// cborDecMode decodes nested maps as map[string]any like the other decoders, instead of the
+// map[any]any default of the cbor package.
+var cborDecMode, _ = cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]any{})}.DecMode()
+
+// RegisterBodyDecoder registers the decoder of request bodies with the given media type, such as
+// application/x-protobuf, replacing the built-in one if any. Bodies with a structured syntax suffix,
+// like application/vnd.api+json, fall back to the decoder of application/json.
+func RegisterBodyDecoder(mediaType string, decoder BodyDecoder) {
+	bodyDecodersLock.Lock()
+	defer bodyDecodersLock.Unlock()
+
+	bodyDecoders[strings.ToLower(mediaType)] = decoder
+}
+
+func getBodyDecoder(contentType string) (BodyDecoder, bool) {
+	mediaType := strings.ToLower(strings.TrimSpace(binder.FilterFlags(contentType)))
+	if mediaType == "" {
+		return nil, false
+	}
+
+	bodyDecodersLock.RLock()
+	defer bodyDecodersLock.RUnlock()
+
+	if decoder, exist := bodyDecoders[mediaType]; exist {
+		return decoder, true
+	}
+	if index := strings.LastIndex(mediaType, "+"); index != -1 {
+		decoder, exist := bodyDecoders["application/"+mediaType[index+1:]]
+		return decoder, exist
+	}
+
+	return nil, false
+}
+
+func decodeCBOR(body []byte, out any) error {
+	return cborDecMode.Unmarshal(body, out)
+}
+
+// decodeXML decodes into structs with encoding/xml. Since encoding/xml can't decode into maps, the
+// document is converted for *map[string]any: the children and attributes of the root element become
+// keys, elements with text only become strings and repeated elements become slices.
+func decodeXML(body []byte, out any) error {
+	data, ok := out.(*map[string]any)
+	if !ok {
+		return xml.Unmarshal(body, out)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return errors.New("xml document has no root element")
+		}
+		if err != nil {
+			return err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return err
+			}
+			if *data == nil {
+				*data = make(map[string]any)
+			}
+			if children, ok := value.(map[string]any); ok {
+				for key, child := range children {
+					(*data)[key] = child
+				}
+			}
+
+			return nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	children := make(map[string]any)
+	for _, attr := range start.Attr {
+		children[attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(decoder, token)
+			if err != nil {
+				return nil, err
+			}
+
+			name := token.Name.Local
+			switch existing := children[name].(type) {
+			case nil:
+				children[name] = value
+			case []any:
+				children[name] = append(existing, value)
+			default:
+				children[name] = []any{existing, value}
+			}
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			if len(children) == 0 {
+				return strings.TrimSpace(text.String()), nil
+			}
+
+			return children, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package chi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBodyDecoder(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expectExist bool
+	}{
+		{contentType: "application/json", expectExist: true},
+		{contentType: "Application/XML; charset=utf-8", expectExist: true},
+		{contentType: "text/yaml", expectExist: true},
+		{contentType: "application/problem+json", expectExist: true},
+		{contentType: "application/atom+xml", expectExist: true},
+		{contentType: "application/x-protobuf", expectExist: false},
+		{contentType: "multipart/form-data; boundary=goravel", expectExist: false},
+		{contentType: "", expectExist: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.contentType, func(t *testing.T) {
+			_, exist := getBodyDecoder(test.contentType)
+			assert.Equal(t, test.expectExist, exist)
+		})
+	}
+}
+
+func TestDecodeXML(t *testing.T) {
+	data := make(map[string]any)
+	err := decodeXML([]byte(`<?xml version="1.0"?>
+<user id="1">
+	<name>Goravel</name>
+	<roles><role>admin</role><role>editor</role></roles>
+	<address><city>Singapore</city></address>
+	<empty/>
+</user>`), &data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"id":      "1",
+		"name":    "Goravel",
+		"roles":   map[string]any{"role": []any{"admin", "editor"}},
+		"address": map[string]any{"city": "Singapore"},
+		"empty":   "",
+	}, data)
+
+	assert.NotNil(t, decodeXML([]byte(""), &data))
+	assert.NotNil(t, decodeXML([]byte("<user><name>Goravel</user>"), &data))
+}
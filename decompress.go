@@ -0,0 +1,122 @@
+package chi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	errBodyTooLarge        = errors.New("decompressed body exceeds the size limit")
+	errUnsupportedEncoding = errors.New("unsupported content encoding")
+)
+
+type DecompressOptions struct {
+	// MaxSize is the maximum size in bytes of a decompressed body, default is the body_limit of the driver.
+	MaxSize int64
+}
+
+// Decompress transparently decodes request bodies sent with a gzip, zstd or br Content-Encoding,
+// so body parsing, Bind and Validate see the original payload. Bodies that exceed MaxSize once
+// decompressed are rejected with 413, unknown encodings with 415 and corrupt data with 400. Encoded
+// bodies aren't parsed before it, so middlewares running earlier see no body input.
+func Decompress(options ...DecompressOptions) contractshttp.Middleware {
+	option := DecompressOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	return func(ctx contractshttp.Context) {
+		// The request body must not be parsed before it's decompressed, so ctx.Request() is avoided here.
+		c, ok := ctx.(*Context)
+		if !ok || c.r.Body == nil || c.r.Header.Get("Content-Encoding") == "" {
+			ctx.Request().Next()
+			return
+		}
+
+		maxSize := option.MaxSize
+		if maxSize <= 0 {
+			maxSize = c.instance.maxMultipartMemory
+		}
+
+		body, err := decompressBody(c.r.Body, strings.Split(c.r.Header.Get("Content-Encoding"), ","), maxSize)
+		_ = c.r.Body.Close()
+		if err != nil {
+			c.aborted = true
+			switch {
+			case errors.Is(err, errUnsupportedEncoding):
+				abortWithStatus(c.instance, c.w, c.r, http.StatusUnsupportedMediaType)
+			case errors.Is(err, errBodyTooLarge):
+				abortWithStatus(c.instance, c.w, c.r, http.StatusRequestEntityTooLarge)
+			default:
+				abortWithStatus(c.instance, c.w, c.r, http.StatusBadRequest)
+			}
+
+			return
+		}
+
+		c.r.Body = io.NopCloser(bytes.NewReader(body))
+		c.r.ContentLength = int64(len(body))
+		c.r.Header.Del("Content-Encoding")
+		c.r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+		c.callNext()
+	}
+}
+
+// decompressBody removes the encodings in the reverse order they were applied.
+func decompressBody(body io.Reader, encodings []string, maxSize int64) ([]byte, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		encoding := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if encoding == "" || encoding == "identity" {
+			continue
+		}
+
+		reader, err := newDecompressReader(encoding, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		data, err = io.ReadAll(io.LimitReader(reader, maxSize+1))
+		_ = reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		if int64(len(data)) > maxSize {
+			return nil, errBodyTooLarge
+		}
+	}
+
+	return data, nil
+}
+
+func newDecompressReader(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case EncodingGzip, "x-gzip":
+		return gzip.NewReader(r)
+	case EncodingZstd:
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+
+		return decoder.IOReadCloser(), nil
+	case EncodingBrotli:
+		return io.NopCloser(brotli.NewReader(r)), nil
+	default:
+		return nil, errUnsupportedEncoding
+	}
+}
@@ -0,0 +1,189 @@
+package chi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	logmocks "github.com/goravel/framework/mocks/log"
+	"github.com/goravel/framework/validation"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecompress(t *testing.T) {
+	var mockConfig *configmocks.Config
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+		ValidationFacade = validation.NewValidation()
+	}
+
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name            string
+		options         []DecompressOptions
+		contentEncoding string
+		body            []byte
+		expectCode      int
+	}{
+		{
+			name:            "gzip",
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, []byte(`{"name":"Goravel"}`)),
+			expectCode:      http.StatusOK,
+		},
+		{
+			name:            "zstd",
+			contentEncoding: "zstd",
+			body:            zstdBytes(t, []byte(`{"name":"Goravel"}`)),
+			expectCode:      http.StatusOK,
+		},
+		{
+			name:            "stacked encodings",
+			contentEncoding: "gzip, zstd",
+			body:            zstdBytes(t, gzipBytes(t, []byte(`{"name":"Goravel"}`))),
+			expectCode:      http.StatusOK,
+		},
+		{
+			name:       "not encoded",
+			body:       []byte(`{"name":"Goravel"}`),
+			expectCode: http.StatusOK,
+		},
+		{
+			name:            "exceeds max size",
+			options:         []DecompressOptions{{MaxSize: 1024}},
+			contentEncoding: "gzip",
+			body:            gzipBytes(t, []byte(`{"name":"`+strings.Repeat("a", 2048)+`"}`)),
+			expectCode:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:            "unsupported encoding",
+			contentEncoding: "compress",
+			body:            []byte(`{"name":"Goravel"}`),
+			expectCode:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:            "corrupt body",
+			contentEncoding: "gzip",
+			body:            []byte(`{"name":"Goravel"}`),
+			expectCode:      http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			route.Middleware(Decompress(test.options...)).Post("/", func(ctx contractshttp.Context) contractshttp.Response {
+				validator, err := ctx.Request().Validate(map[string]string{
+					"name": "required",
+				})
+				assert.Nil(t, err)
+				assert.False(t, validator.Fails())
+
+				var user User
+				assert.Nil(t, validator.Bind(&user))
+
+				return ctx.Response().Success().Json(contractshttp.Json{
+					"input":    ctx.Request().Input("name"),
+					"validate": user.Name,
+				})
+			})
+			route.Middleware(Decompress(test.options...)).Post("/bind", func(ctx contractshttp.Context) contractshttp.Response {
+				var user User
+				assert.Nil(t, ctx.Request().Bind(&user))
+
+				return ctx.Response().Success().Json(contractshttp.Json{
+					"bind": user.Name,
+				})
+			})
+
+			w := httptest.NewRecorder()
+			route.ServeHTTP(w, newDecompressRequest(t, "/", test.contentEncoding, test.body))
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectCode == http.StatusOK {
+				assert.Equal(t, "{\"input\":\"Goravel\",\"validate\":\"Goravel\"}\n", w.Body.String())
+			}
+
+			w = httptest.NewRecorder()
+			route.ServeHTTP(w, newDecompressRequest(t, "/bind", test.contentEncoding, test.body))
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectCode == http.StatusOK {
+				assert.Equal(t, "{\"bind\":\"Goravel\"}\n", w.Body.String())
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDecompress_AfterRequestParsing(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+	// Errors of parsing the compressed body would be logged.
+	originLogFacade := LogFacade
+	LogFacade = &logmocks.Log{}
+	defer func() {
+		LogFacade = originLogFacade
+	}()
+
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	route.Middleware(func(ctx contractshttp.Context) {
+		assert.Equal(t, "", ctx.Request().Input("name"))
+		ctx.Request().Next()
+	}, Decompress()).Post("/", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().String(http.StatusOK, ctx.Request().Input("name"))
+	})
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, newDecompressRequest(t, "/", "gzip", gzipBytes(t, []byte(`{"name":"Goravel"}`))))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Goravel", w.Body.String())
+	mockConfig.AssertExpectations(t)
+}
+
+func newDecompressRequest(t *testing.T, url, contentEncoding string, body []byte) *http.Request {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	assert.Nil(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
+	return req
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func zstdBytes(t *testing.T, data []byte) []byte {
+	encoder, err := zstd.NewWriter(nil)
+	assert.Nil(t, err)
+
+	return encoder.EncodeAll(data, nil)
+}
@@ -0,0 +1,87 @@
+package chi
+
+import (
+	"net"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	httpcontract "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+)
+
+// domainRoutePrefix is the path prefix the routes of Domain are registered under, followed by the
+// host pattern. dispatchDomain moves the host of requests into their route path, so the single mux
+// routes by host and path at once.
+const domainRoutePrefix = "/__domain__"
+
+// Domain returns a router whose routes only match requests for the host, such as "admin.example.com"
+// or "{tenant}.example.com". Host parameters are read like route parameters, e.g.
+// ctx.Request().Route("tenant"), can be constrained with Where and are filled by Route.URL for the
+// routes named with Group.Name. The routes of a host take precedence over the routes without one.
+func (r *Route) Domain(host string) route.Router {
+	r.instance.domains = true
+
+	return NewGroup(
+		r.config,
+		r.instance,
+		domainRoutePrefix+"/"+strings.ToLower(host),
+		[]httpcontract.Middleware{},
+		[]httpcontract.Middleware{ResponseMiddleware()},
+	)
+}
+
+// dispatchDomain routes requests to the routes of their host when it has a route for the path, the
+// path is cleaned like CleanPath and StripSlashes do. Requests for the paths of domain routes
+// themselves are answered with 404.
+func (i *Instance) dispatchDomain(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		if !i.domains || rctx == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		routePath := rctx.RoutePath
+		if routePath == "" {
+			routePath = r.URL.RawPath
+			if routePath == "" {
+				routePath = r.URL.Path
+			}
+		}
+		if routePath == domainRoutePrefix || strings.HasPrefix(routePath, domainRoutePrefix+"/") {
+			i.mux.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+		if host == "" || strings.ContainsAny(host, "/\\") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		domainPath := domainRoutePrefix + "/" + strings.ToLower(host) + path.Clean("/"+routePath)
+		if routeExists(i.mux, r.Method, domainPath) {
+			rctx.RoutePath = domainPath
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// splitDomainRoute splits a route pattern registered by Domain into its host and path, the host is
+// empty for other routes.
+func splitDomainRoute(pattern string) (string, string) {
+	rest, found := strings.CutPrefix(pattern, domainRoutePrefix+"/")
+	if !found {
+		return "", pattern
+	}
+
+	host, routePath, _ := strings.Cut(rest, "/")
+
+	return host, "/" + routePath
+}
@@ -0,0 +1,156 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomain(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		host        string
+		url         string
+		expectCode  int
+		expectBody  string
+		expectAllow string
+	}{
+		{
+			name:       "static host",
+			host:       "admin.example.com",
+			url:        "/users",
+			expectCode: http.StatusOK,
+			expectBody: "admin users",
+		},
+		{
+			name:       "host parameter",
+			host:       "goravel.example.com:3000",
+			url:        "/users/1",
+			expectCode: http.StatusOK,
+			expectBody: "goravel 1",
+		},
+		{
+			name:       "host is case insensitive",
+			host:       "GORAVEL.example.com",
+			url:        "/users/1",
+			expectCode: http.StatusOK,
+			expectBody: "goravel 1",
+		},
+		{
+			name:       "host parameter when static host has no route",
+			host:       "admin.example.com",
+			url:        "/users/1",
+			expectCode: http.StatusOK,
+			expectBody: "admin 1",
+		},
+		{
+			name:       "host constraint",
+			host:       "tenant-1.example.com",
+			url:        "/users/1",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "route without host",
+			host:       "goravel.example.com",
+			url:        "/health",
+			expectCode: http.StatusOK,
+			expectBody: "ok",
+		},
+		{
+			name:       "domain route shadows route without host",
+			host:       "admin.example.com",
+			url:        "/health",
+			expectCode: http.StatusOK,
+			expectBody: "admin ok",
+		},
+		{
+			name:       "other host",
+			host:       "example.org",
+			url:        "/users",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:        "method not allowed on host",
+			method:      "DELETE",
+			host:        "admin.example.com",
+			url:         "/users",
+			expectCode:  http.StatusMethodNotAllowed,
+			expectAllow: "GET, HEAD, OPTIONS",
+		},
+		{
+			name:       "head",
+			method:     "HEAD",
+			host:       "admin.example.com",
+			url:        "/users",
+			expectCode: http.StatusOK,
+		},
+		{
+			name:       "static files",
+			host:       "admin.example.com",
+			url:        "/assets/app.js",
+			expectCode: http.StatusOK,
+			expectBody: "console.log('goravel')",
+		},
+		{
+			name:       "internal path",
+			host:       "example.org",
+			url:        "/__domain__/admin.example.com/users",
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			r, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			r.Get("/health", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, "ok")
+			})
+			r.Domain("admin.example.com").Group(func(router route.Router) {
+				router.Get("/users", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "admin users")
+				})
+				router.Get("/health", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "admin ok")
+				})
+				router.(*Group).StaticEmbed("/assets", fstest.MapFS{
+					"app.js": {Data: []byte("console.log('goravel')")},
+				})
+			})
+			r.Domain("{tenant}.example.com").(*Group).Where("tenant", "alpha").Group(func(router route.Router) {
+				router.Get("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, ctx.Request().Route("tenant")+" "+ctx.Request().Route("id"))
+				})
+			})
+
+			method := test.method
+			if method == "" {
+				method = "GET"
+			}
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(method, test.url, nil)
+			assert.Nil(t, err)
+			req.Host = test.host
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectBody != "" {
+				assert.Equal(t, test.expectBody, w.Body.String())
+			}
+			assert.Equal(t, test.expectAllow, w.Header().Get("Allow"))
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package chi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+)
+
+type ETagOptions struct {
+	// Weak generates weak validators (W/"...") instead of strong ones.
+	Weak bool
+	// MaxSize is the largest body in bytes that is buffered to generate an ETag, default is 1 MB.
+	// Larger bodies are sent as is.
+	MaxSize int
+}
+
+// ETag generates an ETag from the buffered body of Json, Data, String and Html responses and answers
+// conditional GET and HEAD requests with 304 Not Modified or 412 Precondition Failed. Responses
+// that already carry an ETag or Last-Modified header, such as file responses, are only evaluated,
+// and streamed or partial responses are passed through untouched.
+func ETag(options ...ETagOptions) contractshttp.Middleware {
+	option := ETagOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+	if option.MaxSize <= 0 {
+		option.MaxSize = 1 << 20
+	}
+
+	return func(ctx contractshttp.Context) {
+		request := ctx.Request().Origin()
+		if request.Method != http.MethodGet && request.Method != http.MethodHead {
+			ctx.Request().Next()
+			return
+		}
+
+		ew := &etagWriter{request: request, options: &option}
+		switch ctx := ctx.(type) {
+		case *Context:
+			ew.ResponseWriter = ctx.w
+			ctx.w = ew
+		}
+
+		ctx.Request().Next()
+		ew.Close()
+	}
+}
+
+// EvaluatePreconditions evaluates the conditional headers of the request against the validators
+// of the current representation, following the order of RFC 9110 section 13.2.2. It returns 0 when
+// the request may proceed, otherwise 304 or 412. Handlers of unsafe methods should call it before
+// applying any change. An empty etag means the resource has no current representation and a zero
+// lastModified means its modification time is unknown.
+func EvaluatePreconditions(request *http.Request, etag string, lastModified time.Time) int {
+	if ifMatch := request.Header.Get("If-Match"); ifMatch != "" {
+		if !matchETag(ifMatch, etag, false) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ifUnmodifiedSince := request.Header.Get("If-Unmodified-Since"); ifUnmodifiedSince != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ifUnmodifiedSince); err == nil && lastModified.Truncate(time.Second).After(t) {
+			return http.StatusPreconditionFailed
+		}
+	}
+
+	isSafe := request.Method == http.MethodGet || request.Method == http.MethodHead
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if matchETag(ifNoneMatch, etag, true) {
+			if isSafe {
+				return http.StatusNotModified
+			}
+
+			return http.StatusPreconditionFailed
+		}
+	} else if ifModifiedSince := request.Header.Get("If-Modified-Since"); ifModifiedSince != "" && isSafe && !lastModified.IsZero() {
+		if t, err := http.ParseTime(ifModifiedSince); err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return http.StatusNotModified
+		}
+	}
+
+	return 0
+}
+
+type etagWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	options     *ETagOptions
+	buffer      []byte
+	status      int
+	passthrough bool
+	discard     bool
+	wroteHeader bool
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status != 0 {
+		return
+	}
+
+	w.status = code
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.passthrough && len(w.buffer) == 0 {
+		w.inspect()
+	}
+	if w.discard {
+		return len(b), nil
+	}
+	if w.passthrough {
+		w.writeHeader()
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) > w.options.MaxSize {
+		w.release()
+	}
+
+	return len(b), nil
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *etagWriter) Flush() {
+	if !w.discard {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.release()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *etagWriter) Close() {
+	if w.passthrough || w.discard {
+		w.writeHeader()
+		return
+	}
+	if w.status == 0 {
+		return
+	}
+	if w.status != http.StatusOK {
+		w.release()
+		return
+	}
+
+	header := w.Header()
+	if header.Get("ETag") == "" {
+		header.Set("ETag", generateETag(w.buffer, w.options.Weak))
+	}
+	if code := w.evaluate(); code != 0 {
+		w.buffer = nil
+		w.respond(code)
+		return
+	}
+
+	w.release()
+}
+
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// inspect decides on the first write whether the body needs to be buffered. Responses that aren't
+// 200, that are partial or that already carry validators are never buffered.
+func (w *etagWriter) inspect() {
+	header := w.Header()
+	if w.status != http.StatusOK || header.Get("Content-Range") != "" {
+		w.passthrough = true
+		return
+	}
+	if header.Get("ETag") == "" && header.Get("Last-Modified") == "" {
+		return
+	}
+
+	w.passthrough = true
+	if code := w.evaluate(); code != 0 {
+		w.respond(code)
+	}
+}
+
+func (w *etagWriter) evaluate() int {
+	header := w.Header()
+	var lastModified time.Time
+	if value := header.Get("Last-Modified"); value != "" {
+		lastModified, _ = http.ParseTime(value)
+	}
+
+	return EvaluatePreconditions(w.request, header.Get("ETag"), lastModified)
+}
+
+func (w *etagWriter) respond(code int) {
+	header := w.Header()
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	w.status = code
+	w.discard = true
+	w.writeHeader()
+}
+
+// release stops buffering and sends the buffered body.
+func (w *etagWriter) release() {
+	w.passthrough = true
+	w.writeHeader()
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) > 0 {
+		_, _ = w.ResponseWriter.Write(buffer)
+	}
+}
+
+func (w *etagWriter) writeHeader() {
+	if w.wroteHeader || w.status == 0 {
+		return
+	}
+
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func generateETag(body []byte, weak bool) string {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	if weak {
+		return "W/" + etag
+	}
+
+	return etag
+}
+
+// matchETag reports whether etag is listed in the If-Match or If-None-Match header value. Weak
+// comparison ignores the W/ prefix, strong comparison never matches weak validators.
+func matchETag(header, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+			continue
+		}
+		if !strings.HasPrefix(candidate, "W/") && !strings.HasPrefix(etag, "W/") && candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,262 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	var (
+		mockConfig *configmocks.Config
+		route      *Route
+	)
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	}
+
+	etag := generateETag([]byte("{\"id\":\"1\"}\n"), false)
+	jsonHandler := func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Json(http.StatusOK, contractshttp.Json{"id": "1"})
+	}
+
+	tests := []struct {
+		name       string
+		setup      func()
+		headers    map[string]string
+		expectCode int
+		expectETag string
+		expectBody string
+	}{
+		{
+			name: "generates strong etag",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", jsonHandler)
+			},
+			expectCode: http.StatusOK,
+			expectETag: etag,
+			expectBody: "{\"id\":\"1\"}\n",
+		},
+		{
+			name: "generates weak etag",
+			setup: func() {
+				route.Middleware(ETag(ETagOptions{Weak: true})).Get("/", jsonHandler)
+			},
+			expectCode: http.StatusOK,
+			expectETag: "W/" + etag,
+			expectBody: "{\"id\":\"1\"}\n",
+		},
+		{
+			name: "If-None-Match matches",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-None-Match": `"other", ` + etag},
+			expectCode: http.StatusNotModified,
+			expectETag: etag,
+		},
+		{
+			name: "If-None-Match uses weak comparison",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-None-Match": "W/" + etag},
+			expectCode: http.StatusNotModified,
+			expectETag: etag,
+		},
+		{
+			name: "If-None-Match does not match",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-None-Match": `"other"`},
+			expectCode: http.StatusOK,
+			expectETag: etag,
+			expectBody: "{\"id\":\"1\"}\n",
+		},
+		{
+			name: "If-Match does not match",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-Match": `"other"`},
+			expectCode: http.StatusPreconditionFailed,
+			expectETag: etag,
+		},
+		{
+			name: "If-Match uses strong comparison",
+			setup: func() {
+				route.Middleware(ETag(ETagOptions{Weak: true})).Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-Match": "W/" + etag},
+			expectCode: http.StatusPreconditionFailed,
+			expectETag: "W/" + etag,
+		},
+		{
+			name: "If-Modified-Since with Last-Modified set by handler",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Header("Last-Modified", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)).
+						String(http.StatusOK, "Goravel")
+				})
+			},
+			headers:    map[string]string{"If-Modified-Since": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)},
+			expectCode: http.StatusNotModified,
+		},
+		{
+			name: "If-Unmodified-Since with Last-Modified set by handler",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Header("Last-Modified", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)).
+						String(http.StatusOK, "Goravel")
+				})
+			},
+			headers:    map[string]string{"If-Unmodified-Since": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat)},
+			expectCode: http.StatusPreconditionFailed,
+		},
+		{
+			name: "file response is evaluated by Last-Modified",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().File("./test.txt")
+				})
+			},
+			headers:    map[string]string{"If-Modified-Since": time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)},
+			expectCode: http.StatusNotModified,
+		},
+		{
+			name: "error responses are not tagged",
+			setup: func() {
+				route.Middleware(ETag()).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusNotFound, "not found")
+				})
+			},
+			expectCode: http.StatusNotFound,
+			expectBody: "not found",
+		},
+		{
+			name: "disabled without middleware",
+			setup: func() {
+				route.Get("/", jsonHandler)
+			},
+			headers:    map[string]string{"If-None-Match": etag},
+			expectCode: http.StatusOK,
+			expectBody: "{\"id\":\"1\"}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			var err error
+			route, err = NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			test.setup()
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "/", nil)
+			assert.Nil(t, err)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectETag, w.Header().Get("ETag"))
+			assert.Equal(t, test.expectBody, w.Body.String())
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEvaluatePreconditions(t *testing.T) {
+	lastModified := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		method     string
+		headers    map[string]string
+		etag       string
+		expectCode int
+	}{
+		{
+			name:       "no conditional headers",
+			method:     http.MethodPut,
+			etag:       `"a"`,
+			expectCode: 0,
+		},
+		{
+			name:       "If-Match matches",
+			method:     http.MethodPut,
+			headers:    map[string]string{"If-Match": `"a"`},
+			etag:       `"a"`,
+			expectCode: 0,
+		},
+		{
+			name:       "If-Match does not match",
+			method:     http.MethodPut,
+			headers:    map[string]string{"If-Match": `"b"`},
+			etag:       `"a"`,
+			expectCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "If-Match any without representation",
+			method:     http.MethodPut,
+			headers:    map[string]string{"If-Match": "*"},
+			expectCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "If-None-Match any on unsafe method",
+			method:     http.MethodPut,
+			headers:    map[string]string{"If-None-Match": "*"},
+			etag:       `"a"`,
+			expectCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "If-Unmodified-Since is ignored when If-Match is present",
+			method:     http.MethodPut,
+			headers:    map[string]string{"If-Match": `"a"`, "If-Unmodified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)},
+			etag:       `"a"`,
+			expectCode: 0,
+		},
+		{
+			name:       "If-Unmodified-Since fails",
+			method:     http.MethodDelete,
+			headers:    map[string]string{"If-Unmodified-Since": lastModified.Add(-time.Hour).Format(http.TimeFormat)},
+			expectCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "If-Modified-Since is ignored when If-None-Match is present",
+			method:     http.MethodGet,
+			headers:    map[string]string{"If-None-Match": `"b"`, "If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			etag:       `"a"`,
+			expectCode: 0,
+		},
+		{
+			name:       "If-Modified-Since is ignored on unsafe method",
+			method:     http.MethodPost,
+			headers:    map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			expectCode: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, "/", nil)
+			assert.Nil(t, err)
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, test.expectCode, EvaluatePreconditions(req, test.etag, lastModified))
+		})
+	}
+}
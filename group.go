@@ -1,9 +1,14 @@
 package chi
 
 import (
+	"fmt"
+	"io/fs"
+	"maps"
 	"net/http"
+	"regexp"
 	"strings"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/goravel/framework/contracts/config"
 	httpcontract "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/route"
@@ -17,8 +22,27 @@ type Group struct {
 	originMiddlewares []httpcontract.Middleware
 	middlewares       []httpcontract.Middleware
 	lastMiddlewares   []httpcontract.Middleware
+	originWheres      map[string]string
+	wheres            map[string]string
+	name              string
 }
 
+// routeConstraints are the named constraints of Where, other constraints are regular expressions.
+var routeConstraints = map[string]string{
+	"number":       `[0-9]+`,
+	"alpha":        `[a-zA-Z]+`,
+	"alphanumeric": `[a-zA-Z0-9]+`,
+	"slug":         `[a-z0-9]+(?:-[a-z0-9]+)*`,
+	"uuid":         `[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`,
+	"ulid":         `[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}`,
+}
+
+var routeParamRegexp = regexp.MustCompile(`\{([^{}:]+)\}`)
+
+// routeConstraintNameRegexp matches the constraints taken for a misspelled named constraint instead of
+// a regular expression.
+var routeConstraintNameRegexp = regexp.MustCompile(`^[a-z]+$`)
+
 func NewGroup(config config.Config, instance *Instance, prefix string, originMiddlewares []httpcontract.Middleware, lastMiddlewares []httpcontract.Middleware) route.Router {
 	return &Group{
 		config:            config,
@@ -36,8 +60,14 @@ func (r *Group) Group(handler route.GroupFunc) {
 	r.middlewares = []httpcontract.Middleware{}
 	prefix := r.originPrefix + "/" + r.prefix
 	r.prefix = ""
+	wheres := make(map[string]string)
+	maps.Copy(wheres, r.originWheres)
+	maps.Copy(wheres, r.wheres)
+	r.wheres = nil
 
-	handler(NewGroup(r.config, r.instance, prefix, middlewares, r.lastMiddlewares))
+	group := NewGroup(r.config, r.instance, prefix, middlewares, r.lastMiddlewares).(*Group)
+	group.originWheres = wheres
+	handler(group)
 }
 
 func (r *Group) Prefix(addr string) route.Router {
@@ -52,6 +82,49 @@ func (r *Group) Middleware(middlewares ...httpcontract.Middleware) route.Router
 	return r
 }
 
+// Where constrains a route parameter of the next route, or of every route of the next Group, so
+// URLs whose parameter doesn't match are answered with 404. The constraint is one of number, alpha,
+// alphanumeric, slug, uuid and ulid, an enum such as "in:draft,published", or a regular expression,
+// it panics on an unknown name. Parameters with an inline pattern like {id:[0-9]+} keep it.
+//
+// Where and Name aren't part of route.Router, the routers of the driver are *Group so assert them
+// first, e.g. facades.Route().(*chi.Route).Router.(*chi.Group).Where("id", "number").Get(...).
+// They return *Group, while Prefix and Middleware return route.Router.
+func (r *Group) Where(param, constraint string) *Group {
+	pattern, exist := routeConstraints[constraint]
+	if !exist {
+		if values, found := strings.CutPrefix(constraint, "in:"); found {
+			var quoted []string
+			for _, value := range strings.Split(values, ",") {
+				quoted = append(quoted, regexp.QuoteMeta(strings.TrimSpace(value)))
+			}
+			pattern = strings.Join(quoted, "|")
+		} else if routeConstraintNameRegexp.MatchString(constraint) {
+			panic(fmt.Sprintf("unknown constraint %s of route parameter %s", constraint, param))
+		} else {
+			if _, err := regexp.Compile(constraint); err != nil {
+				panic(fmt.Sprintf("invalid constraint of route parameter %s: %v", param, err))
+			}
+			pattern = constraint
+		}
+	}
+
+	if r.wheres == nil {
+		r.wheres = make(map[string]string)
+	}
+	r.wheres[param] = pattern
+
+	return r
+}
+
+// Name names the next route, so Route.URL generates its URL with the parameters of its path and of
+// the host of Domain, e.g. Domain("{tenant}.example.com").(*Group).Name("users.show").Get(...).
+func (r *Group) Name(name string) *Group {
+	r.name = name
+
+	return r
+}
+
 func (r *Group) Any(relativePath string, handler httpcontract.HandlerFunc) {
 	r.instance.mux.With(r.getMiddlewares()...).Handle(r.getPath(relativePath), handlerToChiHandler(r.instance, handler))
 	r.clearMiddlewares()
@@ -62,6 +135,45 @@ func (r *Group) Get(relativePath string, handler httpcontract.HandlerFunc) {
 	r.clearMiddlewares()
 }
 
+// Match registers the handler under each of the methods, such as []string{"GET", "POST"}.
+func (r *Group) Match(methods []string, relativePath string, handler httpcontract.HandlerFunc) {
+	router := r.instance.mux.With(r.getMiddlewares()...)
+	path := r.getPath(relativePath)
+	chiHandler := handlerToChiHandler(r.instance, handler)
+	for _, method := range methods {
+		router.Method(strings.ToUpper(method), path, chiHandler)
+	}
+	r.clearMiddlewares()
+}
+
+// Mount attaches a handler, such as pprof, a GraphQL server or another chi.Router, under the path
+// prefix with the middlewares of the group. Sub-routers without their own NotFound or MethodNotAllowed
+// handler answer unknown paths and methods like the driver.
+func (r *Group) Mount(prefix string, handler http.Handler) {
+	if subRouter, ok := handler.(*chi.Mux); ok {
+		// chi only assigns the handlers of the parent to the sub-routers that don't have their own.
+		parent := chi.NewRouter()
+		parent.NotFound(func(w http.ResponseWriter, req *http.Request) {
+			r.instance.mux.NotFoundHandler().ServeHTTP(w, req)
+		})
+		parent.MethodNotAllowed(r.instance.methodNotAllowedHandler(subRouter))
+		parent.Mount("/", subRouter)
+	}
+
+	path := strings.TrimSuffix(r.getPath(prefix), "/")
+	if path == "" {
+		path = "/"
+	}
+	r.instance.mux.With(r.getMiddlewares()...).Mount(path, handler)
+	r.clearMiddlewares()
+}
+
+// Handle registers an http.Handler under the method, with the middlewares of the group.
+func (r *Group) Handle(method, relativePath string, handler http.Handler) {
+	r.instance.mux.With(r.getMiddlewares()...).Method(strings.ToUpper(method), r.getPath(relativePath), handler)
+	r.clearMiddlewares()
+}
+
 func (r *Group) Post(relativePath string, handler httpcontract.HandlerFunc) {
 	r.instance.mux.With(r.getMiddlewares()...).Post(r.getPath(relativePath), handlerToChiHandler(r.instance, handler))
 	r.clearMiddlewares()
@@ -87,19 +199,14 @@ func (r *Group) Options(relativePath string, handler httpcontract.HandlerFunc) {
 	r.clearMiddlewares()
 }
 
+// Resource registers the index, store, show, update and destroy routes of a resource, and the create
+// and edit routes when the controller implements ResourceCreateController and ResourceEditController.
 func (r *Group) Resource(relativePath string, controller httpcontract.ResourceController) {
-	r.instance.mux.With(r.getMiddlewares()...).Get(r.getPath(relativePath), handlerToChiHandler(r.instance, controller.Index))
-	r.instance.mux.With(r.getMiddlewares()...).Post(r.getPath(relativePath), handlerToChiHandler(r.instance, controller.Store))
-	r.instance.mux.With(r.getMiddlewares()...).Get(r.getPath(relativePath)+"/{id}", handlerToChiHandler(r.instance, controller.Show))
-	r.instance.mux.With(r.getMiddlewares()...).Put(r.getPath(relativePath)+"/{id}", handlerToChiHandler(r.instance, controller.Update))
-	r.instance.mux.With(r.getMiddlewares()...).Patch(r.getPath(relativePath)+"/{id}", handlerToChiHandler(r.instance, controller.Update))
-	r.instance.mux.With(r.getMiddlewares()...).Delete(r.getPath(relativePath)+"/{id}", handlerToChiHandler(r.instance, controller.Destroy))
-	r.clearMiddlewares()
+	r.resource(relativePath, controller, ResourceOptions{}, true)
 }
 
 func (r *Group) Static(relativePath, root string) {
-	r.StaticFS(r.getPath(relativePath), http.Dir(root))
-	r.clearMiddlewares()
+	r.StaticFS(relativePath, http.Dir(root))
 }
 
 func (r *Group) StaticFile(relativePath, filepath string) {
@@ -113,21 +220,76 @@ func (r *Group) StaticFile(relativePath, filepath string) {
 }
 
 func (r *Group) StaticFS(relativePath string, fs http.FileSystem) {
+	r.StaticFSWithOptions(relativePath, fs, StaticOptions{})
+}
+
+// StaticEmbed serves the files of an fs.FS, such as an embed.FS, under the path prefix. Use fs.Sub
+// to serve a subdirectory of the file system.
+func (r *Group) StaticEmbed(relativePath string, fsys fs.FS, options ...StaticOptions) {
+	option := StaticOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	r.StaticFSWithOptions(relativePath, http.FS(fsys), option)
+}
+
+// StaticFSWithOptions serves the files of an http.FileSystem under the path prefix, with control over
+// cache headers, precompressed files, single-page application fallback, directory listing and
+// hidden files. Missing files are answered by the Fallback handler of the router.
+func (r *Group) StaticFSWithOptions(relativePath string, fs http.FileSystem, options StaticOptions) {
 	if strings.Contains(relativePath, ":") || strings.Contains(relativePath, "*") {
 		panic("URL parameters can not be used when serving a static folder")
 	}
-	fileServer := http.StripPrefix(r.getPath(relativePath), http.FileServer(fs))
-	r.instance.mux.With(r.getMiddlewares()...).Handle(r.getPath(relativePath), fileServer)
+
+	path := strings.TrimSuffix(r.getPath(relativePath), "/")
+	handler := newStaticHandler(r.instance, path, fs, options)
+	middlewares := r.getMiddlewares()
+	if path != "" {
+		r.instance.mux.With(middlewares...).Handle(path, handler)
+	}
+	r.instance.mux.With(middlewares...).Handle(path+"/*", handler)
 	r.clearMiddlewares()
 }
 
 func (r *Group) getPath(relativePath string) string {
+	path := r.getRawPath(relativePath)
+	if r.name != "" {
+		r.instance.setRouteName(r.name, path)
+		r.name = ""
+	}
+
+	return r.constrain(path)
+}
+
+// getRawPath is like getPath without the constraints of Where.
+func (r *Group) getRawPath(relativePath string) string {
 	path := r.originPrefix + "/" + r.prefix + "/" + relativePath
 	path = mergeSlashForPath(path)
 	r.prefix = ""
 	return path
 }
 
+// constrain adds the patterns of Where to the parameters of the path.
+func (r *Group) constrain(path string) string {
+	if len(r.originWheres) == 0 && len(r.wheres) == 0 {
+		return path
+	}
+
+	return routeParamRegexp.ReplaceAllStringFunc(path, func(param string) string {
+		name := param[1 : len(param)-1]
+		pattern, exist := r.wheres[name]
+		if !exist {
+			pattern, exist = r.originWheres[name]
+		}
+		if !exist {
+			return param
+		}
+
+		return "{" + name + ":(?:" + pattern + ")}"
+	})
+}
+
 func (r *Group) getMiddlewares() []func(http.Handler) http.Handler {
 	var middlewares []func(http.Handler) http.Handler
 	middlewares = append(middlewares, middlewaresToChiHandlers(r.instance, r.originMiddlewares)...)
@@ -139,4 +301,6 @@ func (r *Group) getMiddlewares() []func(http.Handler) http.Handler {
 
 func (r *Group) clearMiddlewares() {
 	r.middlewares = []httpcontract.Middleware{}
+	r.wheres = nil
+	r.name = ""
 }
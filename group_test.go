@@ -1,10 +1,12 @@
 package chi
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	contractshttp "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/route"
 	configmocks "github.com/goravel/framework/mocks/config"
@@ -161,6 +163,189 @@ func TestGroup(t *testing.T) {
 			expectCode: http.StatusOK,
 			expectBody: "{\"id\":\"1\"}\n",
 		},
+		{
+			name: "Head of Get",
+			setup: func(req *http.Request) {
+				chi.Get("/input/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Json(http.StatusOK, contractshttp.Json{
+						"id": ctx.Request().Input("id"),
+					})
+				})
+			},
+			method:     "HEAD",
+			url:        "/input/1",
+			expectCode: http.StatusOK,
+		},
+		{
+			name: "Head defined by the route",
+			setup: func(req *http.Request) {
+				chi.Get("/input/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Json(http.StatusOK, contractshttp.Json{
+						"id": ctx.Request().Input("id"),
+					})
+				})
+				chi.Router.(*Group).Match([]string{"HEAD"}, "/input/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().NoContent(http.StatusAccepted)
+				})
+			},
+			method:     "HEAD",
+			url:        "/input/1",
+			expectCode: http.StatusAccepted,
+		},
+		{
+			name: "Match Get",
+			setup: func(req *http.Request) {
+				chi.Middleware(contextMiddleware()).(*Group).Match([]string{"GET", "post"}, "/match/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().Json(contractshttp.Json{
+						"id":  ctx.Request().Input("id"),
+						"ctx": ctx.Value("ctx"),
+					})
+				})
+			},
+			method:     "GET",
+			url:        "/match/1",
+			expectCode: http.StatusOK,
+			expectBody: "{\"ctx\":\"Goravel\",\"id\":\"1\"}\n",
+		},
+		{
+			name: "Match Post",
+			setup: func(req *http.Request) {
+				chi.Middleware(contextMiddleware()).(*Group).Match([]string{"GET", "post"}, "/match/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().Json(contractshttp.Json{
+						"id":  ctx.Request().Input("id"),
+						"ctx": ctx.Value("ctx"),
+					})
+				})
+			},
+			method:     "POST",
+			url:        "/match/1",
+			expectCode: http.StatusOK,
+			expectBody: "{\"ctx\":\"Goravel\",\"id\":\"1\"}\n",
+		},
+		{
+			name: "Match method not allowed",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Match([]string{"GET", "POST"}, "/match/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+			},
+			method:     "PUT",
+			url:        "/match/1",
+			expectCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "Where number",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "number").Get("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().String(ctx.Request().Route("id"))
+				})
+			},
+			method:     "GET",
+			url:        "/users/12",
+			expectCode: http.StatusOK,
+			expectBody: "12",
+		},
+		{
+			name: "Where number doesn't match",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "number").Get("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().String(ctx.Request().Route("id"))
+				})
+			},
+			method:     "GET",
+			url:        "/users/abc",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name: "Where only applies to the next route",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "number").Get("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+				chi.Get("/posts/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().String(ctx.Request().Route("id"))
+				})
+			},
+			method:     "GET",
+			url:        "/posts/abc",
+			expectCode: http.StatusOK,
+			expectBody: "abc",
+		},
+		{
+			name: "Where uuid and enum",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "uuid").Where("status", "in:draft,published").Get("/posts/{status}/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().String(ctx.Request().Route("status"))
+				})
+			},
+			method:     "GET",
+			url:        "/posts/draft/2c0c9b7e-4b7f-4a8e-9b1a-3f1e6f1d2a3b",
+			expectCode: http.StatusOK,
+			expectBody: "draft",
+		},
+		{
+			name: "Where enum doesn't match",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("status", "in:draft,published").Get("/posts/{status}", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+			},
+			method:     "GET",
+			url:        "/posts/drafts",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name: "Where regex",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("code", "[A-Z]{3}").Get("/currencies/{code}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().Success().String(ctx.Request().Route("code"))
+				})
+			},
+			method:     "GET",
+			url:        "/currencies/USD",
+			expectCode: http.StatusOK,
+			expectBody: "USD",
+		},
+		{
+			name: "Where on group",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "number").Prefix("users").Group(func(router route.Router) {
+					router.Get("/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+						return nil
+					})
+					router.Get("/{id}/posts/{post}", func(ctx contractshttp.Context) contractshttp.Response {
+						return ctx.Response().Success().String(ctx.Request().Route("post"))
+					})
+				})
+			},
+			method:     "GET",
+			url:        "/users/x/posts/y",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name: "Where on group matches",
+			setup: func(req *http.Request) {
+				chi.Router.(*Group).Where("id", "number").Prefix("users").Group(func(router route.Router) {
+					router.Get("/{id}/posts/{post}", func(ctx contractshttp.Context) contractshttp.Response {
+						return ctx.Response().Success().String(ctx.Request().Route("post"))
+					})
+				})
+			},
+			method:     "GET",
+			url:        "/users/1/posts/y",
+			expectCode: http.StatusOK,
+			expectBody: "y",
+		},
+		{
+			name: "Where on Resource",
+			setup: func(req *http.Request) {
+				resource := resourceController{}
+				chi.Router.(*Group).Where("id", "number").Resource("/resource", resource)
+			},
+			method:     "GET",
+			url:        "/resource/abc",
+			expectCode: http.StatusNotFound,
+		},
 		{
 			name: "Any Get",
 			setup: func(req *http.Request) {
@@ -585,3 +770,230 @@ func contextMiddleware2() contractshttp.Middleware {
 		ctx.Request().Next()
 	}
 }
+
+func TestGroup_WhereInvalidConstraint(t *testing.T) {
+	group := NewGroup(nil, &Instance{}, "", nil, nil).(*Group)
+
+	assert.PanicsWithValue(t, "unknown constraint numbr of route parameter id", func() {
+		group.Where("id", "numbr")
+	})
+	assert.Panics(t, func() {
+		group.Where("code", "[A-Z")
+	})
+	assert.NotPanics(t, func() {
+		group.Where("id", "number").Where("code", "[A-Z]{3}").Where("lang", "en|zh")
+	})
+}
+
+func TestGroup_NextAfterAbort(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	route.Middleware(func(ctx contractshttp.Context) {
+		ctx.Request().AbortWithStatus(http.StatusUnauthorized)
+		ctx.Request().Next()
+	}).Get("/secret", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().String(http.StatusOK, "secret")
+	})
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest("GET", "/secret", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Body.String())
+	mockConfig.AssertExpectations(t)
+}
+
+func TestGroup_HeadOfGet(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	route.Get("/hello", func(ctx contractshttp.Context) contractshttp.Response {
+		_, _ = ctx.Response().Writer().Write([]byte("hello"))
+
+		return nil
+	})
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	getResponse, err := http.Get(server.URL + "/hello")
+	assert.Nil(t, err)
+	getBody, err := io.ReadAll(getResponse.Body)
+	assert.Nil(t, err)
+	_ = getResponse.Body.Close()
+	headResponse, err := http.Head(server.URL + "/hello")
+	assert.Nil(t, err)
+	headBody, err := io.ReadAll(headResponse.Body)
+	assert.Nil(t, err)
+	_ = headResponse.Body.Close()
+
+	assert.Equal(t, http.StatusOK, headResponse.StatusCode)
+	assert.Equal(t, "hello", string(getBody))
+	assert.Empty(t, headBody)
+	assert.Equal(t, int64(5), getResponse.ContentLength)
+	assert.Equal(t, getResponse.ContentLength, headResponse.ContentLength)
+	assert.Equal(t, "text/plain; charset=utf-8", getResponse.Header.Get("Content-Type"))
+	assert.Equal(t, getResponse.Header.Get("Content-Type"), headResponse.Header.Get("Content-Type"))
+	mockConfig.AssertExpectations(t)
+}
+
+func TestGroup_Mount(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		expectCode   int
+		expectBody   string
+		expectHeader map[string]string
+	}{
+		{
+			name:       "handler",
+			method:     "GET",
+			url:        "/admin/debug/vars",
+			expectCode: http.StatusOK,
+			expectBody: "/admin/debug/vars",
+			expectHeader: map[string]string{
+				"Group": "admin",
+			},
+		},
+		{
+			name:       "sub-router",
+			method:     "GET",
+			url:        "/admin/users/1",
+			expectCode: http.StatusOK,
+			expectBody: "user 1",
+			expectHeader: map[string]string{
+				"Group": "admin",
+			},
+		},
+		{
+			name:       "sub-router not found",
+			method:     "GET",
+			url:        "/admin/users/1/posts",
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name:       "sub-router method not allowed",
+			method:     "DELETE",
+			url:        "/admin/users/1",
+			expectCode: http.StatusMethodNotAllowed,
+			expectHeader: map[string]string{
+				"Allow": "GET, HEAD, OPTIONS",
+			},
+		},
+		{
+			name:       "sub-router with its own not found",
+			method:     "GET",
+			url:        "/admin/teams/1/posts",
+			expectCode: http.StatusNotFound,
+			expectBody: "team not found",
+		},
+		{
+			name:       "handle",
+			method:     "POST",
+			url:        "/admin/graphql",
+			expectCode: http.StatusOK,
+			expectBody: "graphql",
+			expectHeader: map[string]string{
+				"Group": "admin",
+			},
+		},
+		{
+			name:       "handle other method",
+			method:     "GET",
+			url:        "/admin/graphql",
+			expectCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			r, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+
+			subRouter := chi.NewRouter()
+			subRouter.Get("/{id}", func(w http.ResponseWriter, req *http.Request) {
+				_, _ = w.Write([]byte("user " + chi.URLParam(req, "id")))
+			})
+			teamRouter := chi.NewRouter()
+			teamRouter.Get("/{id}", func(w http.ResponseWriter, req *http.Request) {
+				_, _ = w.Write([]byte("team " + chi.URLParam(req, "id")))
+			})
+			teamRouter.NotFound(func(w http.ResponseWriter, req *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("team not found"))
+			})
+			r.Prefix("admin").Middleware(func(ctx contractshttp.Context) {
+				ctx.Response().Header("Group", "admin")
+				ctx.Request().Next()
+			}).Group(func(router route.Router) {
+				router.(*Group).Mount("/debug", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					_, _ = w.Write([]byte(req.URL.Path))
+				}))
+				router.(*Group).Mount("/users", subRouter)
+				router.(*Group).Mount("/teams", teamRouter)
+				router.(*Group).Handle("post", "/graphql", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					_, _ = w.Write([]byte("graphql"))
+				}))
+			})
+			r.Fallback(func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusNotFound, "fallback")
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.url, nil)
+			assert.Nil(t, err)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectBody != "" {
+				assert.Equal(t, test.expectBody, w.Body.String())
+			}
+			for key, value := range test.expectHeader {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGroup_MountRoot(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+	r, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	subRouter := chi.NewRouter()
+	subRouter.Get("/", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("home"))
+	})
+	subRouter.Get("/about", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("about"))
+	})
+	assert.NotPanics(t, func() {
+		r.Router.(*Group).Mount("/", subRouter)
+	})
+
+	for url, body := range map[string]string{"/": "home", "/about": "about"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		assert.Equal(t, http.StatusOK, w.Code, url)
+		assert.Equal(t, body, w.Body.String(), url)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/contact", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockConfig.AssertExpectations(t)
+}
@@ -0,0 +1,110 @@
+package chi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/go-rat/chix"
+)
+
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+// JSONValues yields the values of NDJSON and JSONArray responses until yield returns false, see
+// ChannelValues and SeqValues.
+type JSONValues func(ctx context.Context, yield func(value any) bool)
+
+// ChannelValues yields the values received from the channel until it's closed or the context is
+// done. Producers should stop sending once the context is done too, nobody receives then.
+func ChannelValues[T any](ch <-chan T) JSONValues {
+	return func(ctx context.Context, yield func(value any) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case value, ok := <-ch:
+				if !ok || !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqValues yields the values of an iterator, such as an iter.Seq, which must stop when yield
+// returns false.
+func SeqValues[T any](seq func(yield func(T) bool)) JSONValues {
+	return func(ctx context.Context, yield func(value any) bool) {
+		seq(func(value T) bool {
+			return yield(value)
+		})
+	}
+}
+
+// JSONStreamResponse encodes values one at a time as newline delimited JSON or as the items of a
+// JSON array, flushing each of them. Values are consumed as fast as the client reads them.
+type JSONStreamResponse struct {
+	code   int
+	array  bool
+	values JSONValues
+	render *chix.Render
+	w      http.ResponseWriter
+	r      *http.Request
+}
+
+// Render stops when the client disconnects or a value can't be encoded, the status code has been sent
+// already then, JSON arrays are left unterminated so clients see the response is incomplete.
+func (r *JSONStreamResponse) Render() error {
+	if r.array {
+		r.render.Header("Content-Type", chix.MIMEApplicationJSONCharsetUTF8)
+	} else {
+		r.render.Header("Content-Type", MIMEApplicationNDJSON)
+	}
+	r.w.Header().Del("Content-Length")
+	r.render.Status(r.code)
+
+	ctx := r.r.Context()
+	writer := NewStreamWriter(r.render, r.w)
+	writer.ctx = ctx
+	separator := "["
+	var err error
+	r.values(ctx, func(value any) bool {
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+
+		var body []byte
+		if body, err = encodeJSON(value); err != nil {
+			return false
+		}
+		if r.array {
+			body = append([]byte(separator), bytes.TrimSuffix(body, []byte("\n"))...)
+			separator = ","
+		}
+		if _, err = writer.Write(body); err != nil {
+			return false
+		}
+		err = writer.Flush()
+
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	if r.array {
+		if separator == "[" {
+			_, err = writer.WriteString("[]")
+		} else {
+			_, err = writer.WriteString("]")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
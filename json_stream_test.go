@@ -0,0 +1,157 @@
+package chi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamUser struct {
+	Name string `json:"name"`
+}
+
+func streamUsers(names ...string) func(yield func(streamUser) bool) {
+	return func(yield func(streamUser) bool) {
+		for _, name := range names {
+			if !yield(streamUser{Name: name}) {
+				return
+			}
+		}
+	}
+}
+
+func TestJSONStream(t *testing.T) {
+	tests := []struct {
+		name              string
+		response          func(ctx contractshttp.Context) contractshttp.Response
+		expectCode        int
+		expectContentType string
+		expectBody        string
+	}{
+		{
+			name: "ndjson from a sequence",
+			response: func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().(*ContextResponse).NDJSON(http.StatusOK, SeqValues(streamUsers("goravel", "chi")))
+			},
+			expectCode:        http.StatusOK,
+			expectContentType: MIMEApplicationNDJSON,
+			expectBody:        "{\"name\":\"goravel\"}\n{\"name\":\"chi\"}\n",
+		},
+		{
+			name: "ndjson from a channel",
+			response: func(ctx contractshttp.Context) contractshttp.Response {
+				ch := make(chan int)
+				go func() {
+					defer close(ch)
+					for i := 1; i <= 3; i++ {
+						ch <- i
+					}
+				}()
+
+				return ctx.Response().Status(http.StatusCreated).(*Status).NDJSON(ChannelValues(ch))
+			},
+			expectCode:        http.StatusCreated,
+			expectContentType: MIMEApplicationNDJSON,
+			expectBody:        "1\n2\n3\n",
+		},
+		{
+			name: "json array",
+			response: func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().(*ContextResponse).JSONArray(http.StatusOK, SeqValues(streamUsers("goravel", "chi")))
+			},
+			expectCode:        http.StatusOK,
+			expectContentType: "application/json; charset=utf-8",
+			expectBody:        "[{\"name\":\"goravel\"},{\"name\":\"chi\"}]",
+		},
+		{
+			name: "empty json array",
+			response: func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().Success().(*Status).JSONArray(SeqValues(streamUsers()))
+			},
+			expectCode:        http.StatusOK,
+			expectContentType: "application/json; charset=utf-8",
+			expectBody:        "[]",
+		},
+		{
+			name: "unencodable value",
+			response: func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().(*ContextResponse).JSONArray(http.StatusOK, SeqValues(func(yield func(any) bool) {
+					_ = yield(1) && yield(func() {}) && yield(3)
+				}))
+			},
+			expectCode:        http.StatusOK,
+			expectContentType: "application/json; charset=utf-8",
+			expectBody:        "[1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			route.Get("/stream", test.response)
+
+			w := httptest.NewRecorder()
+			route.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil))
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectBody, w.Body.String())
+			assert.True(t, w.Flushed)
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJSONStream_Disconnect(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+
+	renderErr := make(chan error, 1)
+	route.Get("/stream", func(ctx contractshttp.Context) contractshttp.Response {
+		ch := make(chan int)
+		go func() {
+			ch <- 1
+		}()
+
+		renderErr <- ctx.Response().(*ContextResponse).NDJSON(http.StatusOK, ChannelValues(ch)).Render()
+
+		return nil
+	})
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/stream", nil)
+	assert.Nil(t, err)
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	line, err := bufio.NewReader(response.Body).ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "1\n", line)
+
+	cancel()
+	select {
+	case err := <-renderErr:
+		assert.True(t, errors.Is(err, context.Canceled))
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream wasn't stopped when the client disconnected")
+	}
+}
@@ -0,0 +1,154 @@
+package chi
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-rat/chix"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MIMEApplicationYAML    = "application/yaml"
+	MIMEApplicationMsgPack = "application/msgpack"
+)
+
+type negotiateEncoder func(data any) ([]byte, error)
+
+// negotiateOffers lists the media types Negotiate may respond with in order of preference, the
+// first one is used when the request has no Accept header. text/html is offered separately since it
+// needs a view. Partial offers are only made when the data can be encoded, e.g. XML can't encode maps.
+var negotiateOffers = []struct {
+	mediaType   string
+	contentType string
+	encode      negotiateEncoder
+	partial     bool
+}{
+	{chix.MIMEApplicationJSON, chix.MIMEApplicationJSONCharsetUTF8, encodeJSON, false},
+	{chix.MIMEApplicationXML, chix.MIMEApplicationXMLCharsetUTF8, encodeXML, true},
+	{chix.MIMETextXML, chix.MIMETextXMLCharsetUTF8, encodeXML, true},
+	{MIMEApplicationYAML, MIMEApplicationYAML + "; charset=utf-8", yaml.Marshal, false},
+	{"application/x-yaml", "application/x-yaml; charset=utf-8", yaml.Marshal, false},
+	{"text/yaml", "text/yaml; charset=utf-8", yaml.Marshal, false},
+	{MIMEApplicationMsgPack, MIMEApplicationMsgPack, msgpack.Marshal, false},
+	{"application/x-msgpack", "application/x-msgpack", msgpack.Marshal, false},
+	{"application/vnd.msgpack", "application/vnd.msgpack", msgpack.Marshal, false},
+	{chix.MIMETextPlain, chix.MIMETextPlainCharsetUTF8, encodeText, true},
+}
+
+var errNotText = errors.New("data can't be rendered as plain text")
+
+func encodeJSON(data any) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	encoder := chix.JSONEncoder(buffer)
+	encoder.SetEscapeHTML(true)
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func encodeXML(data any) ([]byte, error) {
+	body, err := xml.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// encodeText renders strings, byte slices, errors and fmt.Stringer values, other data has no
+// sensible plain text representation.
+func encodeText(data any) ([]byte, error) {
+	switch data := data.(type) {
+	case string:
+		return []byte(data), nil
+	case []byte:
+		return data, nil
+	case error:
+		return []byte(data.Error()), nil
+	case fmt.Stringer:
+		return []byte(data.String()), nil
+	}
+
+	return nil, errNotText
+}
+
+type acceptRange struct {
+	mediaType string
+	params    map[string]string
+	quality   float64
+}
+
+// parseAccept parses the media ranges of an Accept header, parameters other than q are kept.
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, quality := parseQuality(part)
+		if mediaType == "" {
+			continue
+		}
+
+		params := make(map[string]string)
+		_, rawParams, _ := strings.Cut(part, ";")
+		for _, param := range strings.Split(rawParams, ";") {
+			key, value, found := strings.Cut(param, "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if !found || key == "q" {
+				continue
+			}
+			params[key] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+
+		ranges = append(ranges, acceptRange{mediaType: mediaType, params: params, quality: quality})
+	}
+
+	return ranges
+}
+
+// negotiateMediaType picks the offer with the highest q-value in the Accept header, the q-value of an
+// offer comes from its most specific matching range and ties are broken by the order of the offers.
+// An empty Accept header accepts the first offer, an empty result means nothing is acceptable.
+func negotiateMediaType(accept string, offers []string) string {
+	if strings.TrimSpace(accept) == "" {
+		if len(offers) == 0 {
+			return ""
+		}
+
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := "", 0.0
+	for _, offer := range offers {
+		offerType, _, _ := strings.Cut(offer, "/")
+		quality, specificity := 0.0, 0
+		for _, item := range ranges {
+			itemType, itemSubtype, _ := strings.Cut(item.mediaType, "/")
+			var current int
+			switch {
+			case item.mediaType == offer:
+				current = 3
+			case itemType == offerType && itemSubtype == "*":
+				current = 2
+			case item.mediaType == "*/*" || item.mediaType == "*":
+				current = 1
+			default:
+				continue
+			}
+			if current > specificity {
+				quality, specificity = item.quality, current
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
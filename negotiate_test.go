@@ -0,0 +1,42 @@
+package chi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateMediaType(t *testing.T) {
+	offers := []string{"application/json", "text/html", "application/xml", "text/plain"}
+	tests := []struct {
+		name   string
+		accept string
+		expect string
+	}{
+		{name: "empty", accept: "", expect: "application/json"},
+		{name: "exact", accept: "application/xml", expect: "application/xml"},
+		{name: "case insensitive", accept: "Application/XML", expect: "application/xml"},
+		{name: "q-value", accept: "application/json;q=0.4, text/plain;q=0.8", expect: "text/plain"},
+		{name: "tie keeps offer order", accept: "text/plain, application/xml", expect: "application/xml"},
+		{name: "subtype wildcard", accept: "text/*", expect: "text/html"},
+		{name: "browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expect: "text/html"},
+		{name: "most specific range wins", accept: "text/*;q=0.9, text/html;q=0.1", expect: "text/plain"},
+		{name: "refused", accept: "application/json;q=0", expect: ""},
+		{name: "nothing acceptable", accept: "image/png", expect: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expect, negotiateMediaType(test.accept, offers))
+		})
+	}
+}
+
+func TestParseAccept(t *testing.T) {
+	ranges := parseAccept(`application/vnd.goravel+json; version="2"; q=0.5, */*`)
+
+	assert.Equal(t, []acceptRange{
+		{mediaType: "application/vnd.goravel+json", params: map[string]string{"version": "2"}, quality: 0.5},
+		{mediaType: "*/*", params: map[string]string{}, quality: 1},
+	}, ranges)
+}
@@ -0,0 +1,116 @@
+package chi
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	contractsvalidate "github.com/goravel/framework/contracts/validation"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ProblemDetails is an RFC 9457 problem details object.
+type ProblemDetails struct {
+	// Type is a URI reference identifying the problem type, default is about:blank.
+	Type string
+	// Title is a short summary of the problem type, default is the status text when Type is about:blank.
+	Title string
+	// Status is the HTTP status code, default is 500.
+	Status int
+	// Detail explains this occurrence of the problem.
+	Detail string
+	// Instance is a URI reference identifying this occurrence, default is the request path.
+	Instance string
+	// Extensions are additional members, they can't override the members above.
+	Extensions map[string]any
+}
+
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	} else {
+		delete(members, "detail")
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	} else {
+		delete(members, "instance")
+	}
+
+	return json.Marshal(members)
+}
+
+// NewValidationProblem builds a 422 problem listing the failed rules of each field in the errors member.
+func NewValidationProblem(errors contractsvalidate.Errors) ProblemDetails {
+	return ProblemDetails{
+		Status: http.StatusUnprocessableEntity,
+		Detail: "The given data was invalid.",
+		Extensions: map[string]any{
+			"errors": errors.All(),
+		},
+	}
+}
+
+type ProblemResponse struct {
+	problem ProblemDetails
+	w       http.ResponseWriter
+	r       *http.Request
+}
+
+func (r *ProblemResponse) Render() error {
+	return writeProblem(r.w, r.r, r.problem)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, problem ProblemDetails) error {
+	if problem.Status == 0 {
+		problem.Status = http.StatusInternalServerError
+	}
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" && problem.Type == "about:blank" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Instance == "" && r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(true)
+	if err := encoder.Encode(problem); err != nil {
+		// The error may reveal the extensions, it's logged instead of being sent.
+		if LogFacade != nil && r != nil {
+			LogFacade.WithContext(r.Context()).Errorf("encode problem details of %s %s error: %v", r.Method, r.URL.Path, err)
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return err
+	}
+
+	w.Header().Set("Content-Type", MIMEApplicationProblemJSON)
+	w.Header().Del("Content-Length")
+	w.WriteHeader(problem.Status)
+	_, err := w.Write(buffer.Bytes())
+
+	return err
+}
+
+// abortWithStatus answers a request the driver refuses, with a problem details body when they are
+// enabled and with the status code only otherwise.
+func abortWithStatus(instance *Instance, w http.ResponseWriter, r *http.Request, code int) {
+	if instance != nil && instance.problemDetails {
+		_ = writeProblem(w, r, ProblemDetails{Status: code})
+		return
+	}
+
+	w.WriteHeader(code)
+}
@@ -0,0 +1,244 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	logmocks "github.com/goravel/framework/mocks/log"
+	"github.com/goravel/framework/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProblemDetails(t *testing.T) {
+	var (
+		mockConfig *configmocks.Config
+		route      *Route
+	)
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+		ValidationFacade = validation.NewValidation()
+	}
+
+	tests := []struct {
+		name         string
+		setup        func()
+		method       string
+		url          string
+		header       map[string]string
+		expectCode   int
+		expectBody   string
+		expectHeader map[string]string
+	}{
+		{
+			name: "Problem",
+			setup: func() {
+				route.Get("/orders/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().(*ContextResponse).Problem(ProblemDetails{
+						Type:       "https://example.com/probs/out-of-credit",
+						Title:      "You do not have enough credit.",
+						Status:     http.StatusForbidden,
+						Detail:     "Your current balance is 30, but that costs 50.",
+						Extensions: map[string]any{"balance": 30, "status": 200},
+					})
+				})
+			},
+			url:        "/orders/1",
+			expectCode: http.StatusForbidden,
+			expectBody: `{"balance":30,"detail":"Your current balance is 30, but that costs 50.","instance":"/orders/1","status":403,"title":"You do not have enough credit.","type":"https://example.com/probs/out-of-credit"}` + "\n",
+			expectHeader: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "Problem with defaults",
+			setup: func() {
+				route.Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().(*ContextResponse).Problem(ProblemDetails{Status: http.StatusConflict})
+				})
+			},
+			url:        "/",
+			expectCode: http.StatusConflict,
+			expectBody: `{"instance":"/","status":409,"title":"Conflict","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "validation problem",
+			setup: func() {
+				route.Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					validator, err := ctx.Request().Validate(map[string]string{"name": "required"})
+					assert.Nil(t, err)
+
+					return ctx.Response().(*ContextResponse).Problem(NewValidationProblem(validator.Errors()))
+				})
+			},
+			url:        "/",
+			expectCode: http.StatusUnprocessableEntity,
+			expectBody: `{"detail":"The given data was invalid.","errors":{"name":{"required":"name is required to not be empty"}},"instance":"/","status":422,"title":"Unprocessable Entity","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "not found is untouched when disabled",
+			setup: func() {
+				route.Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+			},
+			url:        "/missing",
+			expectCode: http.StatusNotFound,
+			expectBody: "404 page not found\n",
+		},
+		{
+			name: "not found",
+			setup: func() {
+				route.EnableProblemDetails()
+			},
+			url:        "/missing",
+			expectCode: http.StatusNotFound,
+			expectBody: `{"instance":"/missing","status":404,"title":"Not Found","type":"about:blank"}` + "\n",
+			expectHeader: map[string]string{
+				"Content-Type": "application/problem+json",
+			},
+		},
+		{
+			name: "fallback is kept",
+			setup: func() {
+				route.Fallback(func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusNotFound, "fallback")
+				})
+				route.EnableProblemDetails()
+			},
+			url:        "/missing",
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name: "method not allowed",
+			setup: func() {
+				route.EnableProblemDetails()
+				route.Get("/users", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+				route.Post("/users", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+			},
+			method:     http.MethodDelete,
+			url:        "/users",
+			expectCode: http.StatusMethodNotAllowed,
+			expectBody: `{"instance":"/users","status":405,"title":"Method Not Allowed","type":"about:blank"}` + "\n",
+			expectHeader: map[string]string{
+				"Allow": "GET, HEAD, POST, OPTIONS",
+			},
+		},
+		{
+			name: "AbortWithStatus",
+			setup: func() {
+				route.EnableProblemDetails()
+				route.Middleware(func(ctx contractshttp.Context) {
+					ctx.Request().AbortWithStatus(http.StatusTooManyRequests)
+				}).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "Goravel")
+				})
+			},
+			url:        "/",
+			expectCode: http.StatusTooManyRequests,
+			expectBody: `{"instance":"/","status":429,"title":"Too Many Requests","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "AbortWithStatus without problem details",
+			setup: func() {
+				route.Middleware(func(ctx contractshttp.Context) {
+					ctx.Request().AbortWithStatus(http.StatusTooManyRequests)
+				}).Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "Goravel")
+				})
+			},
+			url:        "/",
+			expectCode: http.StatusTooManyRequests,
+		},
+		{
+			name: "Decompress",
+			setup: func() {
+				route.EnableProblemDetails()
+				route.Middleware(Decompress()).Post("/", func(ctx contractshttp.Context) contractshttp.Response {
+					return nil
+				})
+			},
+			method:     http.MethodPost,
+			url:        "/",
+			header:     map[string]string{"Content-Encoding": "compress"},
+			expectCode: http.StatusUnsupportedMediaType,
+			expectBody: `{"instance":"/","status":415,"title":"Unsupported Media Type","type":"about:blank"}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			var err error
+			route, err = NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			test.setup()
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, test.url, http.NoBody)
+			assert.Nil(t, err)
+			for key, value := range test.header {
+				req.Header.Set(key, value)
+			}
+
+			w := httptest.NewRecorder()
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectBody, w.Body.String())
+			for key, value := range test.expectHeader {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProblemDetails_EncodeError(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+
+	mockWriter := &logmocks.Writer{}
+	mockWriter.On("Errorf", "encode problem details of %s %s error: %v", http.MethodGet, "/orders/1", mock.Anything).Return().Once()
+	mockLog := &logmocks.Log{}
+	mockLog.On("WithContext", mock.Anything).Return(mockWriter).Once()
+	originLogFacade := LogFacade
+	LogFacade = mockLog
+	t.Cleanup(func() {
+		LogFacade = originLogFacade
+	})
+
+	route.Get("/orders/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().(*ContextResponse).Problem(ProblemDetails{
+			Status:     http.StatusForbidden,
+			Extensions: map[string]any{"secret": func() {}},
+		})
+	})
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest("GET", "/orders/1", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "Internal Server Error\n", w.Body.String())
+	mockConfig.AssertExpectations(t)
+	mockLog.AssertExpectations(t)
+	mockWriter.AssertExpectations(t)
+}
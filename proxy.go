@@ -0,0 +1,422 @@
+package chi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ProxyBalancer string
+
+const (
+	ProxyRoundRobin       ProxyBalancer = "round_robin"
+	ProxyLeastConnections ProxyBalancer = "least_connections"
+)
+
+type ProxyOptions struct {
+	// Balancer picks the upstream of each request, default is ProxyRoundRobin.
+	Balancer ProxyBalancer
+	// HealthCheck is the path requested on every upstream each HealthCheckInterval, upstreams that
+	// don't answer it with 2xx or 3xx are skipped until they do. Upstreams aren't checked when empty.
+	HealthCheck string
+	// HealthCheckInterval defaults to 10 seconds.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout defaults to 5 seconds.
+	HealthCheckTimeout time.Duration
+	// StripPrefix removes the prefix of the route from the forwarded path.
+	StripPrefix bool
+	// Rewrite rewrites the forwarded path, after StripPrefix.
+	Rewrite func(path string) string
+	// PreserveHost forwards the Host header of the request instead of the host of the upstream.
+	PreserveHost bool
+	// RequestHeaders are set on the forwarded requests, an empty value removes the header.
+	RequestHeaders map[string]string
+	// ResponseHeaders are set on the responses of the upstreams, an empty value removes the header.
+	ResponseHeaders map[string]string
+	// Retries is the number of times idempotent requests without a body are retried on another
+	// upstream when the upstream can't be reached.
+	Retries int
+	// FlushInterval is the interval the response body is flushed to the client while it's copied, a
+	// negative value flushes after each write. Streamed responses, like text/event-stream, are always
+	// flushed immediately.
+	FlushInterval time.Duration
+	// Transport defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+var errNoUpstream = errors.New("no healthy upstream")
+
+// Proxy forwards the requests under the path prefix to the targets, such as "http://10.0.0.1:8080",
+// with the middlewares of the group except ResponseMiddleware, so response bodies are streamed
+// without being captured. Request bodies are streamed through GlobalMiddleware and the middlewares of
+// the group too, unless one reads the body input with ctx.Request().
+// WebSocket upgrades are passed through. It panics if a target isn't a valid absolute URL.
+func (r *Group) Proxy(prefix string, targets []string, options ...ProxyOptions) {
+	option := ProxyOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	p := newProxy(r.instance, targets, option)
+	r.instance.proxies = append(r.instance.proxies, p)
+
+	var middlewares []func(http.Handler) http.Handler
+	middlewares = append(middlewares, streamingMiddlewaresToChiHandlers(r.instance, r.originMiddlewares)...)
+	middlewares = append(middlewares, streamingMiddlewaresToChiHandlers(r.instance, r.middlewares)...)
+
+	path := strings.TrimSuffix(r.getPath(prefix), "/")
+	if path != "" {
+		r.instance.mux.With(middlewares...).Handle(path, p.handler)
+		p.patterns = append(p.patterns, path)
+	}
+	r.instance.mux.With(middlewares...).Handle(path+"/*", p.handler)
+	p.patterns = append(p.patterns, path+"/*")
+	r.clearMiddlewares()
+}
+
+// isProxyRequest reports whether the request is routed to a proxy, GlobalMiddleware doesn't read the
+// bodies of these requests in advance.
+func (i *Instance) isProxyRequest(r *http.Request) bool {
+	if len(i.proxies) == 0 || r.Body == nil || r.Body == http.NoBody {
+		return false
+	}
+
+	routePath := r.URL.RawPath
+	if routePath == "" {
+		routePath = r.URL.Path
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		routePath = rctx.RoutePath
+	}
+
+	rctx := chi.NewRouteContext()
+	if !i.mux.Match(rctx, r.Method, routePath) {
+		return false
+	}
+	for _, p := range i.proxies {
+		if slices.Contains(p.patterns, rctx.RoutePattern()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type proxyUpstream struct {
+	url     *url.URL
+	active  atomic.Int64
+	healthy atomic.Bool
+}
+
+type proxy struct {
+	patterns  []string
+	upstreams []*proxyUpstream
+	options   ProxyOptions
+	transport http.RoundTripper
+	handler   *httputil.ReverseProxy
+	next      atomic.Uint64
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+func newProxy(instance *Instance, targets []string, options ProxyOptions) *proxy {
+	if len(targets) == 0 {
+		panic("proxy targets can't be empty")
+	}
+
+	p := &proxy{options: options, transport: options.Transport, stop: make(chan struct{})}
+	if p.transport == nil {
+		p.transport = http.DefaultTransport
+	}
+	for _, target := range targets {
+		targetURL, err := url.Parse(target)
+		if err != nil || targetURL.Scheme == "" || targetURL.Host == "" {
+			panic("invalid proxy target " + target)
+		}
+
+		upstream := &proxyUpstream{url: targetURL}
+		upstream.healthy.Store(true)
+		p.upstreams = append(p.upstreams, upstream)
+	}
+
+	p.handler = &httputil.ReverseProxy{
+		Rewrite:        p.rewrite,
+		Transport:      p,
+		FlushInterval:  options.FlushInterval,
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			code := http.StatusBadGateway
+			var netErr net.Error
+			switch {
+			case errors.Is(err, context.Canceled):
+				// The client went away, nobody reads the response.
+				return
+			case errors.Is(err, errNoUpstream):
+				code = http.StatusServiceUnavailable
+			case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+				code = http.StatusGatewayTimeout
+			}
+
+			if LogFacade != nil {
+				LogFacade.WithContext(r.Context()).Errorf("proxy %s %s error: %v", r.Method, r.URL.Path, err)
+			}
+			abortWithStatus(instance, w, r, code)
+		},
+	}
+
+	if options.HealthCheck != "" {
+		go p.checkHealth()
+	}
+
+	return p
+}
+
+func (p *proxy) rewrite(pr *httputil.ProxyRequest) {
+	pr.SetXForwarded()
+
+	path := pr.Out.URL.Path
+	if p.options.StripPrefix {
+		path = "/" + chi.URLParam(pr.In, "*")
+	}
+	if p.options.Rewrite != nil {
+		path = p.options.Rewrite(path)
+	}
+	if path != pr.Out.URL.Path {
+		pr.Out.URL.Path = path
+		pr.Out.URL.RawPath = ""
+	}
+
+	for key, value := range p.options.RequestHeaders {
+		if value == "" {
+			pr.Out.Header.Del(key)
+		} else {
+			pr.Out.Header.Set(key, value)
+		}
+	}
+}
+
+func (p *proxy) modifyResponse(response *http.Response) error {
+	for key, value := range p.options.ResponseHeaders {
+		if value == "" {
+			response.Header.Del(key)
+		} else {
+			response.Header.Set(key, value)
+		}
+	}
+
+	return nil
+}
+
+// RoundTrip forwards the request to an upstream picked by the balancer, retrying idempotent requests
+// on other upstreams when the upstream can't be reached.
+func (p *proxy) RoundTrip(req *http.Request) (*http.Response, error) {
+	retries := 0
+	if isIdempotent(req.Method) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil) {
+		retries = p.options.Retries
+	}
+
+	tried := make(map[*proxyUpstream]bool)
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		upstream := p.pick(tried)
+		if upstream == nil {
+			break
+		}
+		tried[upstream] = true
+
+		out := req.Clone(req.Context())
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			out.Body = body
+		}
+		out.URL.Scheme = upstream.url.Scheme
+		out.URL.Host = upstream.url.Host
+		out.URL.Path, out.URL.RawPath = joinProxyPath(upstream.url, out.URL)
+		if upstream.url.RawQuery != "" {
+			if out.URL.RawQuery == "" {
+				out.URL.RawQuery = upstream.url.RawQuery
+			} else {
+				out.URL.RawQuery = upstream.url.RawQuery + "&" + out.URL.RawQuery
+			}
+		}
+		if !p.options.PreserveHost {
+			out.Host = ""
+		}
+
+		upstream.active.Add(1)
+		response, err := p.transport.RoundTrip(out)
+		if err != nil {
+			upstream.active.Add(-1)
+			lastErr = err
+			if req.Context().Err() != nil {
+				break
+			}
+			continue
+		}
+		response.Body = trackProxyBody(response.Body, func() {
+			upstream.active.Add(-1)
+		})
+
+		return response, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errNoUpstream
+	}
+
+	return nil, lastErr
+}
+
+// pick returns a healthy upstream, preferring the ones not tried yet, nil if none is healthy.
+func (p *proxy) pick(tried map[*proxyUpstream]bool) *proxyUpstream {
+	var candidates []*proxyUpstream
+	for _, upstream := range p.upstreams {
+		if upstream.healthy.Load() && !tried[upstream] {
+			candidates = append(candidates, upstream)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, upstream := range p.upstreams {
+			if upstream.healthy.Load() {
+				candidates = append(candidates, upstream)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if p.options.Balancer == ProxyLeastConnections {
+		picked := candidates[0]
+		for _, upstream := range candidates[1:] {
+			if upstream.active.Load() < picked.active.Load() {
+				picked = upstream
+			}
+		}
+
+		return picked
+	}
+
+	return candidates[(p.next.Add(1)-1)%uint64(len(candidates))]
+}
+
+func (p *proxy) checkHealth() {
+	interval := p.options.HealthCheckInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	timeout := p.options.HealthCheckTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	client := &http.Client{Transport: p.transport, Timeout: timeout}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var wg sync.WaitGroup
+		for _, upstream := range p.upstreams {
+			wg.Add(1)
+			go func(upstream *proxyUpstream) {
+				defer wg.Done()
+				upstream.healthy.Store(p.probe(client, upstream))
+			}(upstream)
+		}
+		wg.Wait()
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *proxy) probe(client *http.Client, upstream *proxyUpstream) bool {
+	checkURL := upstream.url.JoinPath(p.options.HealthCheck)
+	response, err := client.Get(checkURL.String())
+	if err != nil {
+		return false
+	}
+	_, _ = io.Copy(io.Discard, response.Body)
+	_ = response.Body.Close()
+
+	return response.StatusCode >= 200 && response.StatusCode < 400
+}
+
+func (p *proxy) close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func joinProxyPath(base, target *url.URL) (string, string) {
+	if base.Path == "" || base.Path == "/" {
+		return target.Path, target.RawPath
+	}
+
+	path := strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(target.Path, "/")
+	if base.RawPath == "" && target.RawPath == "" {
+		return path, ""
+	}
+
+	return path, strings.TrimSuffix(base.EscapedPath(), "/") + "/" + strings.TrimPrefix(target.EscapedPath(), "/")
+}
+
+// trackProxyBody calls done once the response body is closed, the body of upgraded connections keeps
+// being writable for the WebSocket passthrough of httputil.ReverseProxy.
+func trackProxyBody(body io.ReadCloser, done func()) io.ReadCloser {
+	once := &sync.Once{}
+	if upgraded, ok := body.(io.ReadWriteCloser); ok {
+		return &proxyUpgradeBody{ReadWriteCloser: upgraded, once: once, done: done}
+	}
+
+	return &proxyBody{ReadCloser: body, once: once, done: done}
+}
+
+type proxyBody struct {
+	io.ReadCloser
+	once *sync.Once
+	done func()
+}
+
+func (b *proxyBody) Close() error {
+	b.once.Do(b.done)
+
+	return b.ReadCloser.Close()
+}
+
+type proxyUpgradeBody struct {
+	io.ReadWriteCloser
+	once *sync.Once
+	done func()
+}
+
+func (b *proxyUpgradeBody) Close() error {
+	b.once.Do(b.done)
+
+	return b.ReadWriteCloser.Close()
+}
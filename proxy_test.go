@@ -0,0 +1,298 @@
+package chi
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUpstream(t *testing.T, name string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Upstream", name)
+		w.Header().Set("Server", "legacy")
+		_, _ = w.Write([]byte(name + " " + r.Host + " " + r.URL.RequestURI() + " " + r.Header.Get("Tenant") + " " + r.Header.Get("X-Forwarded-Host")))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestProxy(t *testing.T) {
+	one := newUpstream(t, "one")
+	two := newUpstream(t, "two")
+
+	tests := []struct {
+		name         string
+		targets      []string
+		options      ProxyOptions
+		method       string
+		url          string
+		expectCode   int
+		expectBodies []string
+		expectHeader map[string]string
+	}{
+		{
+			name:         "round robin",
+			targets:      []string{one.URL, two.URL},
+			url:          "/legacy/users?page=1",
+			expectCode:   http.StatusOK,
+			expectBodies: []string{"one " + one.Listener.Addr().String() + " /legacy/users?page=1  example.com", "two " + two.Listener.Addr().String() + " /legacy/users?page=1  example.com", "one"},
+		},
+		{
+			name:         "strip prefix and rewrite",
+			targets:      []string{one.URL + "/api"},
+			options:      ProxyOptions{StripPrefix: true, Rewrite: func(path string) string { return path + "/v1" }},
+			url:          "/legacy/users",
+			expectCode:   http.StatusOK,
+			expectBodies: []string{"one " + one.Listener.Addr().String() + " /api/users/v1  example.com"},
+		},
+		{
+			name:         "preserve host",
+			targets:      []string{one.URL},
+			options:      ProxyOptions{PreserveHost: true},
+			url:          "/legacy",
+			expectCode:   http.StatusOK,
+			expectBodies: []string{"one example.com /legacy  example.com"},
+		},
+		{
+			name:    "headers",
+			targets: []string{one.URL},
+			options: ProxyOptions{
+				RequestHeaders:  map[string]string{"Tenant": "goravel"},
+				ResponseHeaders: map[string]string{"Server": "", "Proxy": "chi"},
+			},
+			url:          "/legacy/users",
+			expectCode:   http.StatusOK,
+			expectBodies: []string{"one " + one.Listener.Addr().String() + " /legacy/users goravel example.com"},
+			expectHeader: map[string]string{
+				"Server": "",
+				"Proxy":  "chi",
+			},
+		},
+		{
+			name:         "retry idempotent request",
+			targets:      []string{"http://127.0.0.1:1", one.URL},
+			options:      ProxyOptions{Retries: 1},
+			url:          "/legacy",
+			expectCode:   http.StatusOK,
+			expectBodies: []string{"one", "one"},
+		},
+		{
+			name:       "no retry of post",
+			targets:    []string{"http://127.0.0.1:1", one.URL},
+			options:    ProxyOptions{Retries: 1},
+			method:     "POST",
+			url:        "/legacy",
+			expectCode: http.StatusBadGateway,
+		},
+		{
+			name:       "unreachable upstream",
+			targets:    []string{"http://127.0.0.1:1"},
+			url:        "/legacy",
+			expectCode: http.StatusBadGateway,
+		},
+		{
+			name:       "other path",
+			targets:    []string{one.URL},
+			url:        "/legacies",
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			route := newTestRoute(t)
+			route.Router.(*Group).Proxy("/legacy", test.targets, test.options)
+
+			method := test.method
+			if method == "" {
+				method = "GET"
+			}
+			if len(test.expectBodies) == 0 {
+				test.expectBodies = []string{""}
+			}
+			for _, expectBody := range test.expectBodies {
+				w := httptest.NewRecorder()
+				req, err := http.NewRequest(method, test.url, nil)
+				assert.Nil(t, err)
+				req.Host = "example.com"
+				route.ServeHTTP(w, req)
+
+				assert.Equal(t, test.expectCode, w.Code)
+				assert.True(t, strings.HasPrefix(w.Body.String(), expectBody), w.Body.String())
+				for key, value := range test.expectHeader {
+					assert.Equal(t, value, w.Header().Get(key), key)
+				}
+			}
+		})
+	}
+}
+
+func TestProxy_HealthCheck(t *testing.T) {
+	one := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("one"))
+	}))
+	defer one.Close()
+
+	route := newTestRoute(t)
+	route.Router.(*Group).Proxy("/legacy", []string{one.URL}, ProxyOptions{
+		HealthCheck:         "/health",
+		HealthCheckInterval: 10 * time.Millisecond,
+	})
+
+	assert.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest("GET", "/legacy", nil))
+
+		return w.Code == http.StatusServiceUnavailable
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProxy_LeastConnections(t *testing.T) {
+	p := newProxy(nil, []string{"http://one", "http://two", "http://three"}, ProxyOptions{Balancer: ProxyLeastConnections})
+	p.upstreams[0].active.Store(2)
+	p.upstreams[1].active.Store(1)
+	p.upstreams[2].active.Store(3)
+
+	assert.Equal(t, "two", p.pick(nil).url.Host)
+	assert.Equal(t, "one", p.pick(map[*proxyUpstream]bool{p.upstreams[1]: true}).url.Host)
+
+	p.upstreams[0].healthy.Store(false)
+	p.upstreams[1].healthy.Store(false)
+	assert.Equal(t, "three", p.pick(nil).url.Host)
+
+	p.upstreams[2].healthy.Store(false)
+	assert.Nil(t, p.pick(nil))
+}
+
+func TestProxy_WebSocket(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buffer, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n")
+		_ = buffer.Flush()
+		line, _ := buffer.ReadString('\n')
+		_, _ = buffer.WriteString("echo " + line)
+		_ = buffer.Flush()
+	}))
+	defer upstream.Close()
+
+	route := newTestRoute(t)
+	route.Router.(*Group).Proxy("/ws", []string{upstream.URL})
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	_, err = io.WriteString(conn, "GET /ws HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n")
+	assert.Nil(t, err)
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+
+	_, err = io.WriteString(conn, "goravel\n")
+	assert.Nil(t, err)
+	line, err := reader.ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "echo goravel\n", line)
+}
+
+func TestProxy_StreamedRequestBody(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, route *Route, target string)
+	}{
+		{
+			name: "group middleware",
+			setup: func(t *testing.T, route *Route, target string) {
+				route.Router.Middleware(func(ctx contractshttp.Context) {
+					ctx.Request().Next()
+				}).(*Group).Proxy("/upload", []string{target})
+			},
+		},
+		{
+			name: "global middleware",
+			setup: func(t *testing.T, route *Route, target string) {
+				mockConfig := &configmocks.Config{}
+				mockConfig.EXPECT().Get("cors.paths").Return([]string{"api/*"}).Once()
+				mockConfig.EXPECT().GetString("http.tls.host").Return("").Once()
+				mockConfig.EXPECT().GetString("http.tls.port").Return("").Once()
+				mockConfig.EXPECT().GetString("http.tls.ssl.cert").Return("").Once()
+				mockConfig.EXPECT().GetString("http.tls.ssl.key").Return("").Once()
+				originConfigFacade := ConfigFacade
+				ConfigFacade = mockConfig
+				t.Cleanup(func() {
+					ConfigFacade = originConfigFacade
+					mockConfig.AssertExpectations(t)
+				})
+
+				route.GlobalMiddleware()
+				route.Router.(*Group).Proxy("/upload", []string{target})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			received := make(chan string)
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				buffer := make([]byte, len("chunk"))
+				if _, err := io.ReadFull(r.Body, buffer); err != nil {
+					return
+				}
+				received <- string(buffer)
+				rest, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(rest)
+			}))
+			defer upstream.Close()
+
+			route := newTestRoute(t)
+			test.setup(t, route, upstream.URL)
+
+			body, writer := io.Pipe()
+			req := httptest.NewRequest("POST", "/upload", body)
+			w := httptest.NewRecorder()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				route.ServeHTTP(w, req)
+			}()
+
+			_, err := writer.Write([]byte("chunk"))
+			assert.Nil(t, err)
+			select {
+			case chunk := <-received:
+				assert.Equal(t, "chunk", chunk)
+			case <-time.After(5 * time.Second):
+				t.Fatal("the request body was buffered before being proxied")
+			}
+			_, err = writer.Write([]byte("rest"))
+			assert.Nil(t, err)
+			assert.Nil(t, writer.Close())
+			<-done
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "rest", w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package chi
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-chi/chi/v5/middleware"
+	contractshttp "github.com/goravel/framework/contracts/http"
+)
+
+type RecoverOptions struct {
+	// Reporters are called with every recovered panic, e.g. to send it to an error tracker.
+	Reporters []func(ctx contractshttp.Context, err error)
+	// Response renders the response of a recovered panic, such as ctx.Response().Json,
+	// ContextResponse.Problem or an error view. Default is a 500 problem details body when they are
+	// enabled and an empty 500 response otherwise. It isn't called when the response has already
+	// started, since its status and headers were sent.
+	Response func(ctx contractshttp.Context, err error) contractshttp.Response
+}
+
+// Recover configures how the recovery middleware installed by GlobalMiddleware handles panics.
+// Panics are logged through LogFacade with the request method, URL and stack before the reporters
+// are called.
+func (r *Route) Recover(options RecoverOptions) {
+	r.instance.recoverOptions = options
+}
+
+// recoverer recovers from panics of the handler chain, it replaces middleware.Recoverer so panics are
+// logged, reported and rendered like the rest of the application.
+func recoverer(instance *Instance) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					if rvr == http.ErrAbortHandler { //nolint:errorlint
+						// Let the server abort the response, as middleware.Recoverer does.
+						panic(rvr)
+					}
+
+					handlePanic(instance, ww, r, rvr, debug.Stack())
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+func handlePanic(instance *Instance, w middleware.WrapResponseWriter, r *http.Request, rvr any, stack []byte) {
+	err, ok := rvr.(error)
+	if !ok {
+		err = fmt.Errorf("%v", rvr)
+	}
+
+	if LogFacade != nil {
+		LogFacade.WithContext(r.Context()).With(map[string]any{
+			"method": r.Method,
+			"url":    r.URL.String(),
+			"stack":  string(stack),
+		}).Errorf("panic recovered: %v", err)
+	} else {
+		middleware.PrintPrettyStack(rvr)
+	}
+
+	ctx := NewContext(instance, w, r)
+	for _, reporter := range instance.recoverOptions.Reporters {
+		reporter(ctx, err)
+	}
+
+	if w.Status() != 0 || r.Header.Get("Connection") == "Upgrade" {
+		return
+	}
+	if instance.recoverOptions.Response != nil {
+		if response := instance.recoverOptions.Response(ctx, err); response != nil {
+			_ = response.Render()
+			return
+		}
+	}
+
+	abortWithStatus(instance, w, r, http.StatusInternalServerError)
+}
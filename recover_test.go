@@ -0,0 +1,154 @@
+package chi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	logmocks "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRecoverer(t *testing.T) {
+	var (
+		mockConfig *configmocks.Config
+		mockLog    *logmocks.Log
+		mockWriter *logmocks.Writer
+		route      *Route
+		reported   []error
+	)
+	originLogFacade := LogFacade
+	defer func() {
+		LogFacade = originLogFacade
+	}()
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+		mockWriter = &logmocks.Writer{}
+		mockWriter.On("With", mock.MatchedBy(func(data map[string]any) bool {
+			return data["method"] == http.MethodGet && data["url"] == "/panic" && data["stack"] != ""
+		})).Return(mockWriter).Once()
+		mockWriter.On("Errorf", "panic recovered: %v", mock.Anything).Once()
+		mockLog = &logmocks.Log{}
+		mockLog.On("WithContext", mock.Anything).Return(mockWriter).Once()
+		LogFacade = mockLog
+
+		reported = nil
+	}
+	reporter := func(ctx contractshttp.Context, err error) {
+		reported = append(reported, err)
+	}
+	errGoravel := errors.New("goravel")
+
+	tests := []struct {
+		name         string
+		setup        func()
+		expectCode   int
+		expectBody   string
+		expectReport bool
+	}{
+		{
+			name: "default response",
+			setup: func() {
+				route.Get("/panic", func(ctx contractshttp.Context) contractshttp.Response {
+					panic("goravel")
+				})
+			},
+			expectCode: http.StatusInternalServerError,
+		},
+		{
+			name: "problem details",
+			setup: func() {
+				route.EnableProblemDetails()
+				route.Get("/panic", func(ctx contractshttp.Context) contractshttp.Response {
+					panic("goravel")
+				})
+			},
+			expectCode: http.StatusInternalServerError,
+			expectBody: `{"instance":"/panic","status":500,"title":"Internal Server Error","type":"about:blank"}` + "\n",
+		},
+		{
+			name: "custom response and reporters",
+			setup: func() {
+				route.Recover(RecoverOptions{
+					Reporters: []func(ctx contractshttp.Context, err error){reporter},
+					Response: func(ctx contractshttp.Context, err error) contractshttp.Response {
+						return ctx.Response().Json(http.StatusInternalServerError, contractshttp.Json{"message": err.Error()})
+					},
+				})
+				route.Get("/panic", func(ctx contractshttp.Context) contractshttp.Response {
+					panic(errGoravel)
+				})
+			},
+			expectCode:   http.StatusInternalServerError,
+			expectBody:   "{\"message\":\"goravel\"}\n",
+			expectReport: true,
+		},
+		{
+			name: "response already started",
+			setup: func() {
+				route.Recover(RecoverOptions{
+					Reporters: []func(ctx contractshttp.Context, err error){reporter},
+					Response: func(ctx contractshttp.Context, err error) contractshttp.Response {
+						return ctx.Response().String(http.StatusInternalServerError, "error")
+					},
+				})
+				route.Get("/panic", func(ctx contractshttp.Context) contractshttp.Response {
+					ctx.Response().Writer().WriteHeader(http.StatusOK)
+					_, _ = ctx.Response().Writer().Write([]byte("partial"))
+					panic(errGoravel)
+				})
+			},
+			expectCode:   http.StatusOK,
+			expectBody:   "partial",
+			expectReport: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			var err error
+			route, err = NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			test.setup()
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/panic", nil)
+			assert.Nil(t, err)
+			recoverer(route.instance)(route).ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectBody, w.Body.String())
+			if test.expectReport {
+				assert.Len(t, reported, 1)
+				assert.ErrorIs(t, reported[0], errGoravel)
+			} else {
+				assert.Empty(t, reported)
+			}
+
+			mockConfig.AssertExpectations(t)
+			mockLog.AssertExpectations(t)
+			mockWriter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	handler := recoverer(&Instance{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, err)
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
@@ -0,0 +1,184 @@
+package chi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	httpcontract "github.com/goravel/framework/contracts/http"
+	"github.com/spf13/cast"
+)
+
+// ResourceCreateController is implemented by resource controllers serving the form creating a
+// resource, registered as GET /photos/create by Resource.
+type ResourceCreateController interface {
+	Create(httpcontract.Context) httpcontract.Response
+}
+
+// ResourceEditController is implemented by resource controllers serving the form editing a resource,
+// registered as GET /photos/{id}/edit by Resource.
+type ResourceEditController interface {
+	Edit(httpcontract.Context) httpcontract.Response
+}
+
+type ResourceOptions struct {
+	// Only registers the actions listed only, of index, create, store, show, edit, update and destroy.
+	Only []string
+	// Except doesn't register the actions listed.
+	Except []string
+	// Parameters names the route parameters of the resources, e.g. {"photos": "photo"}. The default is
+	// id for the resource and the singular of the name for its parents, like photo for photos.
+	Parameters map[string]string
+	// Shallow registers the routes of a nested resource item without its parents, such as
+	// /comments/{id} for photos.comments.
+	Shallow bool
+	// Names overrides the route names of actions, e.g. {"index": "gallery"}. The default is the
+	// resource name followed by the action, like photos.comments.index.
+	Names map[string]string
+}
+
+func (o ResourceOptions) registers(action string) bool {
+	if len(o.Only) > 0 && !slices.Contains(o.Only, action) {
+		return false
+	}
+
+	return !slices.Contains(o.Except, action)
+}
+
+func (o ResourceOptions) parameter(resource string, parent bool) string {
+	if parameter, exist := o.Parameters[resource]; exist {
+		return parameter
+	}
+	if parent {
+		return singular(resource)
+	}
+
+	return "id"
+}
+
+// ResourceWithOptions registers the routes of a resource like Resource, with control over the
+// actions, parameter names and route names. Nested resources are separated by dots, such as
+// "photos.comments" which registers /photos/{photo}/comments/{id}.
+func (r *Group) ResourceWithOptions(relativePath string, controller httpcontract.ResourceController, options ResourceOptions) {
+	r.resource(relativePath, controller, options, true)
+}
+
+// ApiResource registers the routes of a resource without the create and edit forms.
+func (r *Group) ApiResource(relativePath string, controller httpcontract.ResourceController, options ...ResourceOptions) {
+	option := ResourceOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	r.resource(relativePath, controller, option, false)
+}
+
+func (r *Group) resource(relativePath string, controller httpcontract.ResourceController, options ResourceOptions, web bool) {
+	dir, name := path.Split(strings.Trim(relativePath, "/"))
+	resources := strings.Split(name, ".")
+	name = resources[len(resources)-1]
+
+	base := strings.TrimSuffix(r.getRawPath(dir), "/")
+	nested := base
+	for _, parent := range resources[:len(resources)-1] {
+		nested += "/" + parent + "/{" + options.parameter(parent, true) + "}"
+	}
+	collectionPath := nested + "/" + name
+	itemPath := collectionPath + "/{" + options.parameter(name, false) + "}"
+	if options.Shallow {
+		itemPath = base + "/" + name + "/{" + options.parameter(name, false) + "}"
+	}
+
+	middlewares := r.getMiddlewares()
+	register := func(action string, methods []string, pattern string, handler httpcontract.HandlerFunc) {
+		if !options.registers(action) {
+			return
+		}
+
+		chiHandler := handlerToChiHandler(r.instance, handler)
+		for _, method := range methods {
+			r.instance.mux.With(middlewares...).Method(method, r.constrain(pattern), chiHandler)
+		}
+
+		routeName, exist := options.Names[action]
+		if !exist {
+			routeName = strings.Join(resources, ".") + "." + action
+		}
+		r.instance.setRouteName(routeName, pattern)
+	}
+
+	register("index", []string{http.MethodGet}, collectionPath, controller.Index)
+	if creator, ok := controller.(ResourceCreateController); ok && web {
+		register("create", []string{http.MethodGet}, collectionPath+"/create", creator.Create)
+	}
+	register("store", []string{http.MethodPost}, collectionPath, controller.Store)
+	register("show", []string{http.MethodGet}, itemPath, controller.Show)
+	if editor, ok := controller.(ResourceEditController); ok && web {
+		register("edit", []string{http.MethodGet}, itemPath+"/edit", editor.Edit)
+	}
+	register("update", []string{http.MethodPut, http.MethodPatch}, itemPath, controller.Update)
+	register("destroy", []string{http.MethodDelete}, itemPath, controller.Destroy)
+	r.clearMiddlewares()
+}
+
+func (i *Instance) setRouteName(name, pattern string) {
+	if i.routeNames == nil {
+		i.routeNames = make(map[string]string)
+	}
+
+	i.routeNames[name] = pattern
+}
+
+// routeURLParamRegexp matches the parameters of route patterns, with or without an inline pattern.
+var routeURLParamRegexp = regexp.MustCompile(`\{([^{}:]+)(?::[^{}]*)?\}`)
+
+// URL generates the URL of a named route, such as photos.comments.show or a route named with
+// Group.Name, with its parameters. The URL of a route registered by Domain starts with its host, like
+// //goravel.example.com/users.
+func (r *Route) URL(name string, params map[string]any) (string, error) {
+	pattern, exist := r.instance.routeNames[name]
+	if !exist {
+		return "", fmt.Errorf("route %s doesn't exist", name)
+	}
+
+	var missing []string
+	generated := routeURLParamRegexp.ReplaceAllStringFunc(pattern, func(param string) string {
+		key := routeURLParamRegexp.FindStringSubmatch(param)[1]
+		value, exist := params[key]
+		if !exist {
+			missing = append(missing, key)
+			return param
+		}
+
+		return url.PathEscape(cast.ToString(value))
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing parameters of route %s: %s", name, strings.Join(missing, ", "))
+	}
+
+	if host, routePath := splitDomainRoute(generated); host != "" {
+		return "//" + host + routePath, nil
+	}
+
+	return generated, nil
+}
+
+// singular returns the singular of an English resource name in the common cases, use
+// ResourceOptions.Parameters for the others.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "shes"), strings.HasSuffix(name, "ches"),
+		strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "zes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+
+	return name
+}
@@ -0,0 +1,273 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type webResourceController struct{}
+
+func (c webResourceController) Index(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "index "+ctx.Request().Route("photo"))
+}
+
+func (c webResourceController) Create(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "create")
+}
+
+func (c webResourceController) Store(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "store")
+}
+
+func (c webResourceController) Show(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "show "+ctx.Request().Route("photo")+" "+ctx.Request().Route("id"))
+}
+
+func (c webResourceController) Edit(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "edit "+ctx.Request().Route("id"))
+}
+
+func (c webResourceController) Update(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "update "+ctx.Request().Route("id"))
+}
+
+func (c webResourceController) Destroy(ctx contractshttp.Context) contractshttp.Response {
+	return ctx.Response().String(http.StatusOK, "destroy "+ctx.Request().Route("id"))
+}
+
+func TestResource(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(r *Route)
+		method     string
+		url        string
+		expectCode int
+		expectBody string
+	}{
+		{
+			name: "create",
+			setup: func(r *Route) {
+				r.Resource("/photos", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/create",
+			expectCode: http.StatusOK,
+			expectBody: "create",
+		},
+		{
+			name: "edit",
+			setup: func(r *Route) {
+				r.Resource("/photos", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/1/edit",
+			expectCode: http.StatusOK,
+			expectBody: "edit 1",
+		},
+		{
+			name: "create without the action",
+			setup: func(r *Route) {
+				r.Resource("/photos", resourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/create",
+			expectCode: http.StatusOK,
+			expectBody: "{\"action\":null,\"id\":\"create\"}\n",
+		},
+		{
+			name: "api resource has no create",
+			setup: func(r *Route) {
+				r.Router.(*Group).ApiResource("/photos", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/create",
+			expectCode: http.StatusOK,
+			expectBody: "show  create",
+		},
+		{
+			name: "api resource has no edit",
+			setup: func(r *Route) {
+				r.Router.(*Group).ApiResource("/photos", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/1/edit",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name: "only",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("/photos", webResourceController{}, ResourceOptions{Only: []string{"index", "show"}})
+			},
+			method:     "DELETE",
+			url:        "/photos/1",
+			expectCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "except",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("/photos", webResourceController{}, ResourceOptions{Except: []string{"show"}})
+			},
+			method:     "PATCH",
+			url:        "/photos/1",
+			expectCode: http.StatusOK,
+			expectBody: "update 1",
+		},
+		{
+			name: "except leaves out the action",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("/photos", webResourceController{}, ResourceOptions{Except: []string{"show"}})
+			},
+			method:     "GET",
+			url:        "/photos/1",
+			expectCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name: "parameter name",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("/photos", webResourceController{}, ResourceOptions{Parameters: map[string]string{"photos": "photo"}})
+			},
+			method:     "GET",
+			url:        "/photos/1",
+			expectCode: http.StatusOK,
+			expectBody: "show 1 ",
+		},
+		{
+			name: "nested",
+			setup: func(r *Route) {
+				r.Prefix("api").Resource("photos.comments", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/api/photos/1/comments/2",
+			expectCode: http.StatusOK,
+			expectBody: "show 1 2",
+		},
+		{
+			name: "nested index",
+			setup: func(r *Route) {
+				r.Resource("photos.comments", webResourceController{})
+			},
+			method:     "GET",
+			url:        "/photos/1/comments",
+			expectCode: http.StatusOK,
+			expectBody: "index 1",
+		},
+		{
+			name: "shallow",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("photos.comments", webResourceController{}, ResourceOptions{Shallow: true})
+			},
+			method:     "GET",
+			url:        "/comments/2",
+			expectCode: http.StatusOK,
+			expectBody: "show  2",
+		},
+		{
+			name: "shallow keeps the nested collection",
+			setup: func(r *Route) {
+				r.Router.(*Group).ResourceWithOptions("photos.comments", webResourceController{}, ResourceOptions{Shallow: true})
+			},
+			method:     "GET",
+			url:        "/photos/1/comments/2",
+			expectCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			r, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			test.setup(r)
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.url, nil)
+			assert.Nil(t, err)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectBody != "" {
+				assert.Equal(t, test.expectBody, w.Body.String())
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoute_URL(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+	r, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	r.Prefix("api").Resource("photos.comments", webResourceController{})
+	r.Router.(*Group).ResourceWithOptions("categories", webResourceController{}, ResourceOptions{
+		Names: map[string]string{"index": "catalog"},
+	})
+	r.Domain("{tenant}.example.com").Resource("posts", webResourceController{})
+
+	url, err := r.URL("photos.comments.show", map[string]any{"photo": 1, "id": "a b"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/photos/1/comments/a%20b", url)
+
+	url, err = r.URL("photos.comments.edit", map[string]any{"photo": 1, "id": 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/photos/1/comments/2/edit", url)
+
+	url, err = r.URL("catalog", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "/categories", url)
+
+	url, err = r.URL("posts.show", map[string]any{"tenant": "goravel", "id": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "//goravel.example.com/posts/1", url)
+
+	r.Domain("{tenant}.example.com").(*Group).Name("tenant.users.show").Get("/users/{id:[0-9]+}", func(ctx contractshttp.Context) contractshttp.Response {
+		return nil
+	})
+	r.Router.(*Group).Name("home").Get("/", func(ctx contractshttp.Context) contractshttp.Response {
+		return nil
+	})
+
+	url, err = r.URL("tenant.users.show", map[string]any{"tenant": "goravel", "id": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, "//goravel.example.com/users/1", url)
+
+	url, err = r.URL("home", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "/", url)
+
+	_, err = r.URL("tenant.users.show", map[string]any{"id": 1})
+	assert.EqualError(t, err, "missing parameters of route tenant.users.show: tenant")
+
+	_, err = r.URL("photos.comments.show", map[string]any{"id": 1})
+	assert.EqualError(t, err, "missing parameters of route photos.comments.show: photo")
+
+	_, err = r.URL("unknown", nil)
+	assert.EqualError(t, err, "route unknown doesn't exist")
+
+	mockConfig.AssertExpectations(t)
+}
+
+func TestSingular(t *testing.T) {
+	for name, expect := range map[string]string{
+		"photos":     "photo",
+		"categories": "category",
+		"addresses":  "address",
+		"boxes":      "box",
+		"branches":   "branch",
+		"class":      "class",
+		"data":       "data",
+	} {
+		assert.Equal(t, expect, singular(name), name)
+	}
+}
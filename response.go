@@ -1,14 +1,41 @@
 package chi
 
 import (
+	"context"
+	"errors"
 	"html/template"
 	"io"
+	"mime"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/go-rat/chix"
 	contractshttp "github.com/goravel/framework/contracts/http"
 )
 
+type ContentResponse struct {
+	name       string
+	modtime    time.Time
+	content    io.ReadSeeker
+	attachment bool
+	w          http.ResponseWriter
+	r          *http.Request
+}
+
+func (r *ContentResponse) Render() error {
+	if closer, ok := r.content.(io.Closer); ok {
+		defer closer.Close()
+	}
+	if r.attachment {
+		r.w.Header().Set(chix.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": r.name}))
+	}
+
+	http.ServeContent(r.w, r.r, r.name, r.modtime, r.content)
+
+	return nil
+}
+
 type DataResponse struct {
 	code        int
 	contentType string
@@ -60,6 +87,74 @@ func (r *JsonResponse) Render() error {
 	return nil
 }
 
+type NegotiateResponse struct {
+	code   int
+	data   any
+	view   string
+	ctx    *Context
+	render *chix.Render
+}
+
+func (r *NegotiateResponse) Render() error {
+	var offers []string
+	// bodies keeps the bodies encoded to check partial offers.
+	bodies := make(map[string][]byte)
+	for _, offer := range negotiateOffers {
+		if offer.partial {
+			body, err := offer.encode(r.data)
+			if err != nil {
+				continue
+			}
+			bodies[offer.mediaType] = body
+		}
+		offers = append(offers, offer.mediaType)
+		if offer.mediaType == chix.MIMEApplicationJSON && r.view != "" {
+			offers = append(offers, chix.MIMETextHTML)
+		}
+	}
+
+	if !headerContainsToken(r.ctx.w.Header(), "Vary", "Accept") {
+		r.ctx.w.Header().Add("Vary", "Accept")
+	}
+	mediaType := negotiateMediaType(r.ctx.r.Header.Get("Accept"), offers)
+	if mediaType == "" {
+		if r.ctx.instance.problemDetails {
+			return writeProblem(r.ctx.w, r.ctx.r, ProblemDetails{Status: http.StatusNotAcceptable})
+		}
+
+		r.render.Status(http.StatusNotAcceptable)
+		r.render.PlainText(http.StatusText(http.StatusNotAcceptable))
+		return nil
+	}
+	if mediaType == chix.MIMETextHTML {
+		r.ctx.w.Header().Set(chix.HeaderContentType, chix.MIMETextHTMLCharsetUTF8)
+		r.ctx.w.WriteHeader(r.code)
+		return NewView(r.ctx.instance.htmlRender, r.ctx.w).Make(r.view, r.data).Render()
+	}
+
+	for _, offer := range negotiateOffers {
+		if offer.mediaType != mediaType {
+			continue
+		}
+
+		body, ok := bodies[offer.mediaType]
+		if !ok {
+			var err error
+			if body, err = offer.encode(r.data); err != nil {
+				abortWithStatus(r.ctx.instance, r.ctx.w, r.ctx.r, http.StatusInternalServerError)
+				return err
+			}
+		}
+
+		r.render.Status(r.code)
+		r.render.ContentType(offer.contentType)
+		r.render.Data(body)
+		break
+	}
+
+	return nil
+}
+
 type NoContentResponse struct {
 	code   int
 	render *chix.Render
@@ -125,19 +220,53 @@ func (r *HtmlResponse) Render() error {
 }
 
 type StreamResponse struct {
-	code   int
-	render *chix.Render
-	writer func(w contractshttp.StreamWriter) error
+	code     int
+	render   *chix.Render
+	writer   func(w contractshttp.StreamWriter) error
+	ctx      *Context
+	trailers []string
 }
 
+// Trailer declares the HTTP trailers the stream sets with StreamWriter.SetTrailer, in the Trailer
+// header sent before the body.
+func (r *StreamResponse) Trailer(keys ...string) *StreamResponse {
+	r.trailers = append(r.trailers, keys...)
+
+	return r
+}
+
+// Render calls the step function once and flushes what it wrote. Its error is logged, unless the
+// client disconnected, and returned, it's available to middlewares with ContextResponse.Error.
 func (r *StreamResponse) Render() error {
+	if len(r.trailers) > 0 {
+		r.render.Header("Trailer", strings.Join(r.trailers, ", "))
+	}
 	r.render.Status(r.code)
+
+	ctx := context.Background()
+	if r.ctx != nil {
+		ctx = r.ctx.Context()
+	}
+	// The client may have disconnected before the stream starts.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var err error
 	r.render.Stream(func(w io.Writer) bool {
-		if err := r.writer(NewStreamWriter(r.render, w)); err != nil {
-			return false
-		}
-		return true
+		writer := NewStreamWriter(r.render, w)
+		writer.ctx = ctx
+		err = r.writer(writer)
+
+		return false
 	})
+	if err != nil && !errors.Is(err, context.Canceled) && LogFacade != nil {
+		if r.ctx != nil {
+			LogFacade.WithContext(ctx).Errorf("stream %s %s error: %v", r.ctx.r.Method, r.ctx.r.URL.Path, err)
+		} else {
+			LogFacade.WithContext(ctx).Errorf("stream error: %v", err)
+		}
+	}
 
-	return nil
+	return err
 }
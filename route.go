@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -22,6 +23,17 @@ type Instance struct {
 	mux                *chi.Mux
 	htmlRender         *template.Template
 	maxMultipartMemory int64
+	problemDetails     bool
+	fallback           bool
+	recoverOptions     RecoverOptions
+	methodNotAllowed   http.HandlerFunc
+	binders            map[string]RouteBinder
+	domains            bool
+	versioning         VersioningOptions
+	versions           []string
+	routeNames         map[string]string
+	proxies            []*proxy
+	bodyCapture        BodyCaptureOptions
 }
 
 type Route struct {
@@ -33,11 +45,7 @@ type Route struct {
 }
 
 func NewRoute(config config.Config, parameters map[string]any) (*Route, error) {
-	mux := chi.NewRouter()
-	if debugLog := getDebugLog(config); debugLog != nil {
-		mux.Use(debugLog)
-	}
-
+	debugLog := getDebugLog(config)
 	htmlRender, _ := DefaultTemplate()
 	if driver, exist := parameters["driver"]; exist {
 		newHtmlRender, ok := config.Get("http.drivers." + driver.(string) + ".template").(*template.Template)
@@ -56,11 +64,19 @@ func NewRoute(config config.Config, parameters map[string]any) (*Route, error) {
 		}
 	}
 
+	mux := chi.NewRouter()
 	instance := &Instance{
 		mux:                mux,
 		htmlRender:         htmlRender,
 		maxMultipartMemory: int64(config.GetInt("http.drivers.chi.body_limit", 4096)) << 10,
 	}
+	// HEAD requests of paths without a HEAD route are routed to their GET route, net/http discards the
+	// body and keeps the headers the GET handler sends, such as Content-Length.
+	mux.Use(trackResponseError, instance.dispatchDomain, instance.dispatchVersion, middleware.GetHead)
+	if debugLog != nil {
+		mux.Use(debugLog)
+	}
+	mux.MethodNotAllowed(instance.methodNotAllowedHandler(mux))
 
 	return &Route{
 		Router: NewGroup(
@@ -75,14 +91,33 @@ func NewRoute(config config.Config, parameters map[string]any) (*Route, error) {
 	}, nil
 }
 
+// EnableProblemDetails renders the responses of the driver itself, such as 404 without a Fallback,
+// 405, 413 of Decompress, AbortWithStatus with an error code and recovered panics, as RFC 9457
+// application/problem+json. Handlers can use ContextResponse.Problem for their own errors.
+func (r *Route) EnableProblemDetails() {
+	r.instance.problemDetails = true
+	if !r.instance.fallback {
+		r.instance.mux.NotFound(func(w http.ResponseWriter, req *http.Request) {
+			_ = writeProblem(w, req, ProblemDetails{Status: http.StatusNotFound})
+		})
+	}
+}
+
 func (r *Route) Fallback(handler httpcontract.HandlerFunc) {
-	r.instance.mux.NotFound(handlerToChiHandler(r.instance, handler))
+	r.instance.fallback = true
+	r.instance.mux.NotFound(fallbackToChiHandler(r.instance, handler))
+}
+
+// MethodNotAllowed sets the handler of requests whose path matches a route but whose method doesn't.
+// The Allow header listing the methods of the path is set before the handler is called.
+func (r *Route) MethodNotAllowed(handler httpcontract.HandlerFunc) {
+	r.instance.methodNotAllowed = fallbackToChiHandler(r.instance, handler)
 }
 
 func (r *Route) GlobalMiddleware(middlewares ...httpcontract.Middleware) {
 	middlewares = append(middlewares, Cors(), Tls())
-	r.instance.mux.Use(middleware.Recoverer, middleware.CleanPath, middleware.StripSlashes)
-	r.instance.mux.Use(middlewaresToChiHandlers(r.instance, middlewares)...)
+	r.instance.mux.Use(recoverer(r.instance), middleware.CleanPath, middleware.StripSlashes)
+	r.instance.mux.Use(globalMiddlewaresToChiHandlers(r.instance, middlewares)...)
 	r.Router = NewGroup(
 		r.config,
 		r.instance,
@@ -169,6 +204,9 @@ func (r *Route) Shutdown(ctx ...context.Context) error {
 	if len(ctx) > 0 {
 		c = ctx[0]
 	}
+	for _, p := range r.instance.proxies {
+		p.close()
+	}
 
 	if r.server != nil {
 		return r.server.Shutdown(c)
@@ -179,10 +217,36 @@ func (r *Route) Shutdown(ctx ...context.Context) error {
 	return nil
 }
 
+// methodNotAllowedHandler answers requests whose method has no route of the mux for the path. OPTIONS
+// requests are answered with 204 and the Allow header for routes that don't define OPTIONS themselves.
+func (i *Instance) methodNotAllowedHandler(mux *chi.Mux) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods := allowedMethods(mux, r)
+		if !slices.Contains(methods, http.MethodOptions) {
+			methods = append(methods, http.MethodOptions)
+		}
+		w.Header().Set("Allow", strings.Join(methods, ", "))
+
+		switch {
+		case r.Method == http.MethodOptions:
+			w.WriteHeader(http.StatusNoContent)
+		case i.methodNotAllowed != nil:
+			i.methodNotAllowed(w, r)
+		case i.problemDetails:
+			_ = writeProblem(w, r, ProblemDetails{Status: http.StatusMethodNotAllowed})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
 func (r *Route) outputRoutes() {
 	if r.config.GetBool("app.debug") && support.Env != support.EnvArtisan {
 		walkFunc := func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
 			route = strings.Replace(route, "/*/", "/", -1)
+			if host, path := splitDomainRoute(route); host != "" {
+				route = host + path
+			}
 			fmt.Printf("%-10s %s\n", method, route)
 			return nil
 		}
@@ -20,6 +20,31 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestRoute creates a route without debug output and logs, its proxies are closed and the
+// expectations of its config are asserted when the test finishes.
+func newTestRoute(t *testing.T) *Route {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	t.Cleanup(func() {
+		mockConfig.AssertExpectations(t)
+	})
+
+	originLogFacade := LogFacade
+	LogFacade = nil
+	t.Cleanup(func() {
+		LogFacade = originLogFacade
+	})
+
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	t.Cleanup(func() {
+		assert.Nil(t, route.Shutdown())
+	})
+
+	return route
+}
+
 func TestFallback(t *testing.T) {
 	mockConfig := &configmocks.Config{}
 	mockConfig.EXPECT().GetBool("app.debug").Return(true).Once()
@@ -43,6 +68,93 @@ func TestFallback(t *testing.T) {
 	mockConfig.AssertExpectations(t)
 }
 
+func TestMethodNotAllowed(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		custom      bool
+		expectCode  int
+		expectBody  string
+		expectAllow string
+	}{
+		{
+			name:        "default",
+			method:      "DELETE",
+			url:         "/users/1",
+			expectCode:  http.StatusMethodNotAllowed,
+			expectAllow: "GET, HEAD, PUT, OPTIONS",
+		},
+		{
+			name:        "custom handler",
+			method:      "DELETE",
+			url:         "/users/1",
+			custom:      true,
+			expectCode:  http.StatusMethodNotAllowed,
+			expectBody:  "DELETE is not allowed",
+			expectAllow: "GET, HEAD, PUT, OPTIONS",
+		},
+		{
+			name:        "automatic OPTIONS",
+			method:      "OPTIONS",
+			url:         "/users/1",
+			custom:      true,
+			expectCode:  http.StatusNoContent,
+			expectAllow: "GET, HEAD, PUT, OPTIONS",
+		},
+		{
+			name:       "OPTIONS defined by the route",
+			method:     "OPTIONS",
+			url:        "/posts",
+			expectCode: http.StatusOK,
+			expectBody: "options",
+		},
+		{
+			name:       "OPTIONS of unknown path",
+			method:     "OPTIONS",
+			url:        "/unknown",
+			expectCode: http.StatusNotFound,
+			expectBody: "404 page not found\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			if test.custom {
+				route.MethodNotAllowed(func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusMethodNotAllowed, ctx.Request().Method()+" is not allowed")
+				})
+			}
+			route.Get("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+				return nil
+			})
+			route.Put("/users/{id}", func(ctx contractshttp.Context) contractshttp.Response {
+				return nil
+			})
+			route.Options("/posts", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, "options")
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(test.method, test.url, nil)
+			assert.Nil(t, err)
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			assert.Equal(t, test.expectBody, w.Body.String())
+			assert.Equal(t, test.expectAllow, w.Header().Get("Allow"))
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
+
 func TestRun(t *testing.T) {
 	var (
 		err        error
@@ -2,6 +2,7 @@ package chi
 
 import (
 	"github.com/goravel/framework/contracts/config"
+	"github.com/goravel/framework/contracts/database/orm"
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/contracts/log"
@@ -14,6 +15,7 @@ var (
 	App              foundation.Application
 	ConfigFacade     config.Config
 	LogFacade        log.Log
+	OrmFacade        orm.Orm
 	ValidationFacade validation.Validation
 	ViewFacade       http.View
 )
@@ -0,0 +1,186 @@
+package chi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SSEOptions struct {
+	// Heartbeat is the interval of the comments written to keep idle connections open through proxies,
+	// default is 15 seconds, a negative value disables them.
+	Heartbeat time.Duration
+}
+
+var errSSELineBreak = errors.New("sse id and event can't contain line breaks")
+
+// SSEResponse streams Server-Sent Events written by its step function.
+type SSEResponse struct {
+	step    func(w *SSEWriter) error
+	options SSEOptions
+	w       http.ResponseWriter
+	r       *http.Request
+}
+
+func (r *SSEResponse) Render() error {
+	header := r.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Disables the response buffering of nginx.
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+	r.w.WriteHeader(http.StatusOK)
+
+	writer := &SSEWriter{w: r.w, r: r.r, controller: http.NewResponseController(r.w)}
+	if err := writer.flush(); err != nil {
+		return err
+	}
+
+	heartbeat := r.options.Heartbeat
+	if heartbeat == 0 {
+		heartbeat = 15 * time.Second
+	}
+	if heartbeat > 0 {
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			writer.heartbeat(heartbeat, stop)
+		}()
+		defer func() {
+			close(stop)
+			<-done
+		}()
+	}
+
+	return r.step(writer)
+}
+
+// SSEWriter writes the events of an SSEResponse, it's safe for concurrent use.
+type SSEWriter struct {
+	mu         sync.Mutex
+	w          http.ResponseWriter
+	r          *http.Request
+	controller *http.ResponseController
+}
+
+// Context is the context of the request, it's done when the client disconnects.
+func (w *SSEWriter) Context() context.Context {
+	return w.r.Context()
+}
+
+// LastEventID is the ID of the last event received by a reconnecting client.
+func (w *SSEWriter) LastEventID() string {
+	return w.r.Header.Get("Last-Event-ID")
+}
+
+// Event writes and flushes an event. The id, event and retry fields are omitted when empty, data is
+// written as is when it's a string or []byte and as JSON otherwise. It returns the error of the
+// context once the client has disconnected.
+func (w *SSEWriter) Event(id, event string, data any, retry time.Duration) error {
+	if strings.ContainsAny(id, "\r\n\x00") || strings.ContainsAny(event, "\r\n") {
+		return errSSELineBreak
+	}
+
+	var body []byte
+	switch data := data.(type) {
+	case string:
+		body = []byte(data)
+	case []byte:
+		body = data
+	default:
+		encoded, err := encodeJSON(data)
+		if err != nil {
+			return err
+		}
+		body = bytes.TrimSuffix(encoded, []byte("\n"))
+	}
+
+	buffer := new(bytes.Buffer)
+	if id != "" {
+		buffer.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		buffer.WriteString("event: " + event + "\n")
+	}
+	if retry > 0 {
+		buffer.WriteString("retry: " + strconv.FormatInt(retry.Milliseconds(), 10) + "\n")
+	}
+	body = bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n"))
+	body = bytes.ReplaceAll(body, []byte("\r"), []byte("\n"))
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		buffer.WriteString("data: ")
+		buffer.Write(line)
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("\n")
+
+	return w.write(buffer.Bytes())
+}
+
+// Data writes an event with data only.
+func (w *SSEWriter) Data(data any) error {
+	return w.Event("", "", data, 0)
+}
+
+// Comment writes a comment, which clients ignore.
+func (w *SSEWriter) Comment(comment string) error {
+	buffer := new(bytes.Buffer)
+	for _, line := range strings.Split(strings.ReplaceAll(comment, "\r", ""), "\n") {
+		buffer.WriteString(": " + line + "\n")
+	}
+	buffer.WriteString("\n")
+
+	return w.write(buffer.Bytes())
+}
+
+func (w *SSEWriter) write(b []byte) error {
+	if err := w.r.Context().Err(); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.w.Write(b); err != nil {
+		return err
+	}
+
+	return w.flushLocked()
+}
+
+func (w *SSEWriter) flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.flushLocked()
+}
+
+func (w *SSEWriter) flushLocked() error {
+	if err := w.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	return nil
+}
+
+func (w *SSEWriter) heartbeat(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-w.r.Context().Done():
+			return
+		case <-ticker.C:
+			if err := w.write([]byte(":\n\n")); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package chi
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSE(t *testing.T) {
+	route := newTestRoute(t)
+	route.Get("/events", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().(*ContextResponse).SSE(func(w *SSEWriter) error {
+			if err := w.Event("1", "greeting", "hello\ngoravel", 3*time.Second); err != nil {
+				return err
+			}
+			if err := w.Data(map[string]string{"last": w.LastEventID()}); err != nil {
+				return err
+			}
+			if err := w.Comment("bye"); err != nil {
+				return err
+			}
+
+			return w.Event("2\n", "", "", 0)
+		}, SSEOptions{Heartbeat: -1})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/events", nil)
+	req.Header.Set("Last-Event-ID", "0")
+	route.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "id: 1\nevent: greeting\nretry: 3000\ndata: hello\ndata: goravel\n\ndata: {\"last\":\"0\"}\n\n: bye\n\n", w.Body.String())
+	assert.True(t, w.Flushed)
+}
+
+func TestSSE_HeartbeatAndDisconnect(t *testing.T) {
+	stepErr := make(chan error, 1)
+	route := newTestRoute(t)
+	route.Get("/events", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().(*ContextResponse).SSE(func(w *SSEWriter) error {
+			<-w.Context().Done()
+			stepErr <- w.Data("late")
+
+			return nil
+		}, SSEOptions{Heartbeat: 10 * time.Millisecond})
+	})
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/events", nil)
+	assert.Nil(t, err)
+	response, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	reader := bufio.NewReader(response.Body)
+	line, err := reader.ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, ":\n", line)
+
+	cancel()
+	select {
+	case err := <-stepErr:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the step wasn't stopped when the client disconnected")
+	}
+	assert.True(t, strings.HasPrefix(response.Header.Get("Content-Type"), "text/event-stream"))
+}
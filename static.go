@@ -0,0 +1,279 @@
+package chi
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+type StaticOptions struct {
+	// CacheControl is the Cache-Control header sent with every file, e.g. "public, max-age=3600".
+	CacheControl string
+	// Immutable lists path.Match patterns of fingerprinted assets, relative to the prefix such as
+	// "assets/*.js". Matching files are cached by clients for a year without revalidation.
+	Immutable []string
+	// Precompressed serves the .br or .gz sibling of a file instead of the file itself when the
+	// client accepts that encoding.
+	Precompressed bool
+	// SPA serves the index.html of the root for unknown paths under the prefix, so a single-page
+	// application can handle its own routes.
+	SPA bool
+	// DisableListing answers requests for directories without an index.html with 404 instead of
+	// a listing of their files.
+	DisableListing bool
+	// DenyHidden hides files and directories whose name starts with a dot, such as .env or .git.
+	DenyHidden bool
+	// Deny lists path.Match patterns of files that are never served, matched against both the path
+	// relative to the prefix and each of its segments, e.g. "*.map" or "config/*".
+	Deny []string
+}
+
+var precompressedExtensions = map[string]string{
+	EncodingBrotli: ".br",
+	EncodingGzip:   ".gz",
+}
+
+type staticHandler struct {
+	instance *Instance
+	prefix   string
+	fs       http.FileSystem
+	options  StaticOptions
+}
+
+func newStaticHandler(instance *Instance, prefix string, fs http.FileSystem, options StaticOptions) *staticHandler {
+	// The routes of Domain are registered under the host, but it isn't part of the request path.
+	_, prefix = splitDomainRoute(prefix)
+	handler := &staticHandler{instance: instance, prefix: strings.TrimSuffix(prefix, "/"), options: options}
+	handler.fs = &staticFileSystem{FileSystem: fs, denied: handler.denied}
+
+	return handler
+}
+
+func (h *staticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Clean("/" + strings.TrimPrefix(r.URL.Path, h.prefix))
+
+	file, err := h.fs.Open(name)
+	if err != nil {
+		if h.options.SPA && errors.Is(err, fs.ErrNotExist) && !h.denied(name) {
+			h.serveIndex(w, r)
+			return
+		}
+
+		h.error(w, r, err)
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+
+	if stat.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			target := path.Base(r.URL.Path) + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+			return
+		}
+
+		index, err := h.fs.Open(path.Join(name, "index.html"))
+		if err != nil {
+			if h.options.DisableListing {
+				h.error(w, r, fs.ErrNotExist)
+				return
+			}
+
+			// Let the file server render the directory listing, denied files are left out by h.fs.
+			request := r.Clone(r.Context())
+			request.URL.Path = strings.TrimSuffix(name, "/") + "/"
+			http.FileServer(h.fs).ServeHTTP(w, request)
+			return
+		}
+		defer index.Close()
+
+		indexStat, err := index.Stat()
+		if err != nil {
+			h.error(w, r, err)
+			return
+		}
+
+		h.serveFile(w, r, path.Join(name, "index.html"), index, indexStat)
+		return
+	}
+
+	h.serveFile(w, r, name, file, stat)
+}
+
+func (h *staticHandler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := h.fs.Open("/index.html")
+	if err != nil {
+		h.error(w, r, err)
+		return
+	}
+	defer index.Close()
+
+	stat, err := index.Stat()
+	if err != nil || stat.IsDir() {
+		h.error(w, r, fs.ErrNotExist)
+		return
+	}
+
+	h.serveFile(w, r, "/index.html", index, stat)
+}
+
+func (h *staticHandler) serveFile(w http.ResponseWriter, r *http.Request, name string, file http.File, stat fs.FileInfo) {
+	h.setCacheControl(w, name)
+	if h.options.Precompressed && h.servePrecompressed(w, r, name, file, stat) {
+		return
+	}
+
+	http.ServeContent(w, r, stat.Name(), stat.ModTime(), file)
+}
+
+// servePrecompressed serves the sibling of the file with the best encoding accepted by the client,
+// it reports false when there's no acceptable sibling.
+func (h *staticHandler) servePrecompressed(w http.ResponseWriter, r *http.Request, name string, file http.File, stat fs.FileInfo) bool {
+	if !headerContainsToken(w.Header(), "Vary", "Accept-Encoding") {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+
+	siblings := make(map[string]http.File)
+	var available []string
+	for _, encoding := range []string{EncodingBrotli, EncodingGzip} {
+		sibling, err := h.fs.Open(name + precompressedExtensions[encoding])
+		if err != nil {
+			continue
+		}
+		defer sibling.Close()
+
+		if siblingStat, err := sibling.Stat(); err != nil || siblingStat.IsDir() {
+			continue
+		}
+		siblings[encoding] = sibling
+		available = append(available, encoding)
+	}
+
+	encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), available)
+	if encoding == "" {
+		return false
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(name))
+	if contentType == "" {
+		var buffer [512]byte
+		n, _ := io.ReadFull(file, buffer[:])
+		contentType = http.DetectContentType(buffer[:n])
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Encoding", encoding)
+	http.ServeContent(w, r, stat.Name(), stat.ModTime(), siblings[encoding])
+
+	return true
+}
+
+func (h *staticHandler) setCacheControl(w http.ResponseWriter, name string) {
+	relative := strings.TrimPrefix(name, "/")
+	for _, pattern := range h.options.Immutable {
+		if matched, _ := path.Match(pattern, relative); matched {
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			return
+		}
+	}
+
+	if h.options.CacheControl != "" {
+		w.Header().Set("Cache-Control", h.options.CacheControl)
+	}
+}
+
+// denied reports whether the file must not be served because of DenyHidden or Deny.
+func (h *staticHandler) denied(name string) bool {
+	relative := strings.TrimPrefix(path.Clean("/"+name), "/")
+	if relative == "" {
+		return false
+	}
+
+	for _, pattern := range h.options.Deny {
+		if matched, _ := path.Match(pattern, relative); matched {
+			return true
+		}
+	}
+	for _, segment := range strings.Split(relative, "/") {
+		if h.options.DenyHidden && strings.HasPrefix(segment, ".") {
+			return true
+		}
+		for _, pattern := range h.options.Deny {
+			if matched, _ := path.Match(pattern, segment); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// error answers missing files through the Fallback handler of the router, so static 404s look like
+// any other 404 of the application.
+func (h *staticHandler) error(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, fs.ErrNotExist) {
+		h.instance.mux.NotFoundHandler().ServeHTTP(w, r)
+		return
+	}
+
+	code := http.StatusInternalServerError
+	if errors.Is(err, fs.ErrPermission) {
+		code = http.StatusForbidden
+	}
+	if h.instance.problemDetails {
+		_ = writeProblem(w, r, ProblemDetails{Status: code})
+		return
+	}
+
+	http.Error(w, strconv.Itoa(code)+" "+http.StatusText(code), code)
+}
+
+// staticFileSystem hides denied files, both when they are opened and when their directory is listed.
+type staticFileSystem struct {
+	http.FileSystem
+	denied func(name string) bool
+}
+
+func (f *staticFileSystem) Open(name string) (http.File, error) {
+	if f.denied(name) {
+		return nil, fs.ErrNotExist
+	}
+
+	file, err := f.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &staticFile{File: file, name: name, denied: f.denied}, nil
+}
+
+type staticFile struct {
+	http.File
+	name   string
+	denied func(name string) bool
+}
+
+func (f *staticFile) Readdir(count int) ([]fs.FileInfo, error) {
+	infos, err := f.File.Readdir(count)
+	filtered := infos[:0]
+	for _, info := range infos {
+		if !f.denied(path.Join(f.name, info.Name())) {
+			filtered = append(filtered, info)
+		}
+	}
+
+	return filtered, err
+}
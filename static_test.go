@@ -0,0 +1,255 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticEmbed(t *testing.T) {
+	var mockConfig *configmocks.Config
+	beforeEach := func() {
+		mockConfig = &configmocks.Config{}
+		mockConfig.On("GetBool", "app.debug").Return(false).Once()
+		mockConfig.On("GetInt", "http.drivers.chi.body_limit", 4096).Return(4096).Once()
+	}
+
+	modtime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys := fstest.MapFS{
+		"index.html":           {Data: []byte("<html>index</html>"), ModTime: modtime},
+		"app.js":               {Data: []byte("console.log('goravel')"), ModTime: modtime},
+		"app.js.br":            {Data: []byte("brotli"), ModTime: modtime},
+		"app.js.gz":            {Data: []byte("gzip"), ModTime: modtime},
+		"assets/app.1a2b3c.js": {Data: []byte("fingerprinted"), ModTime: modtime},
+		"docs/index.html":      {Data: []byte("<html>docs</html>"), ModTime: modtime},
+		"images/logo.svg":      {Data: []byte("<svg></svg>"), ModTime: modtime},
+		"images/logo.svg.map":  {Data: []byte("{}"), ModTime: modtime},
+		"images/.secret":       {Data: []byte("secret"), ModTime: modtime},
+		".env":                 {Data: []byte("APP_KEY=goravel"), ModTime: modtime},
+		".git/config":          {Data: []byte("[core]"), ModTime: modtime},
+	}
+
+	tests := []struct {
+		name         string
+		options      []StaticOptions
+		fallback     bool
+		url          string
+		header       http.Header
+		expectCode   int
+		expectBody   string
+		expectLinks  []string
+		expectHeader map[string]string
+	}{
+		{
+			name:       "file",
+			url:        "/public/images/logo.svg",
+			expectCode: http.StatusOK,
+			expectBody: "<svg></svg>",
+			expectHeader: map[string]string{
+				"Content-Type":  "image/svg+xml",
+				"Last-Modified": modtime.Format(http.TimeFormat),
+				"Cache-Control": "",
+			},
+		},
+		{
+			name:       "cache control",
+			options:    []StaticOptions{{CacheControl: "public, max-age=3600", Immutable: []string{"assets/*"}}},
+			url:        "/public/app.js",
+			expectCode: http.StatusOK,
+			expectBody: "console.log('goravel')",
+			expectHeader: map[string]string{
+				"Cache-Control": "public, max-age=3600",
+			},
+		},
+		{
+			name:       "immutable asset",
+			options:    []StaticOptions{{CacheControl: "public, max-age=3600", Immutable: []string{"assets/*"}}},
+			url:        "/public/assets/app.1a2b3c.js",
+			expectCode: http.StatusOK,
+			expectBody: "fingerprinted",
+			expectHeader: map[string]string{
+				"Cache-Control": "public, max-age=31536000, immutable",
+			},
+		},
+		{
+			name:       "precompressed brotli",
+			options:    []StaticOptions{{Precompressed: true}},
+			url:        "/public/app.js",
+			header:     http.Header{"Accept-Encoding": {"gzip, br"}},
+			expectCode: http.StatusOK,
+			expectBody: "brotli",
+			expectHeader: map[string]string{
+				"Content-Encoding": "br",
+				"Content-Type":     "text/javascript; charset=utf-8",
+				"Vary":             "Accept-Encoding",
+			},
+		},
+		{
+			name:       "precompressed gzip",
+			options:    []StaticOptions{{Precompressed: true}},
+			url:        "/public/app.js",
+			header:     http.Header{"Accept-Encoding": {"gzip"}},
+			expectCode: http.StatusOK,
+			expectBody: "gzip",
+			expectHeader: map[string]string{
+				"Content-Encoding": "gzip",
+			},
+		},
+		{
+			name:       "precompressed not accepted",
+			options:    []StaticOptions{{Precompressed: true}},
+			url:        "/public/app.js",
+			expectCode: http.StatusOK,
+			expectBody: "console.log('goravel')",
+			expectHeader: map[string]string{
+				"Content-Encoding": "",
+				"Vary":             "Accept-Encoding",
+			},
+		},
+		{
+			name:       "directory index",
+			url:        "/public/docs/",
+			expectCode: http.StatusOK,
+			expectBody: "<html>docs</html>",
+		},
+		{
+			name:       "directory redirect",
+			url:        "/public/docs",
+			expectCode: http.StatusMovedPermanently,
+			expectHeader: map[string]string{
+				"Location": "/public/docs/",
+			},
+		},
+		{
+			name:       "not found",
+			url:        "/public/dashboard/settings",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "not found through fallback",
+			fallback:   true,
+			url:        "/public/dashboard/settings",
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name:        "directory listing",
+			url:         "/public/images/",
+			expectCode:  http.StatusOK,
+			expectLinks: []string{".secret", "logo.svg", "logo.svg.map"},
+		},
+		{
+			name:        "directory listing leaves out denied files",
+			options:     []StaticOptions{{DenyHidden: true, Deny: []string{"*.map"}}},
+			url:         "/public/images/",
+			expectCode:  http.StatusOK,
+			expectLinks: []string{"logo.svg"},
+		},
+		{
+			name:       "directory listing disabled",
+			options:    []StaticOptions{{DisableListing: true}},
+			fallback:   true,
+			url:        "/public/images/",
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name:       "directory listing disabled with index",
+			options:    []StaticOptions{{DisableListing: true}},
+			url:        "/public/docs/",
+			expectCode: http.StatusOK,
+			expectBody: "<html>docs</html>",
+		},
+		{
+			name:       "hidden file is served by default",
+			url:        "/public/.env",
+			expectCode: http.StatusOK,
+			expectBody: "APP_KEY=goravel",
+		},
+		{
+			name:       "hidden file denied",
+			options:    []StaticOptions{{DenyHidden: true}},
+			fallback:   true,
+			url:        "/public/.env",
+			expectCode: http.StatusNotFound,
+			expectBody: "fallback",
+		},
+		{
+			name:       "file in hidden directory denied",
+			options:    []StaticOptions{{DenyHidden: true}},
+			url:        "/public/.git/config",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "denied pattern",
+			options:    []StaticOptions{{Deny: []string{"*.map"}}},
+			url:        "/public/images/logo.svg.map",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "denied path pattern",
+			options:    []StaticOptions{{Deny: []string{"images/*"}}},
+			url:        "/public/images/logo.svg",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "denied file is not replaced by spa fallback",
+			options:    []StaticOptions{{SPA: true, DenyHidden: true}},
+			url:        "/public/.env",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "spa fallback",
+			options:    []StaticOptions{{SPA: true}},
+			url:        "/public/dashboard/settings",
+			expectCode: http.StatusOK,
+			expectBody: "<html>index</html>",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			beforeEach()
+
+			route, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			if test.fallback {
+				route.Fallback(func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusNotFound, "fallback")
+				})
+			}
+			route.Prefix("public").(*Group).StaticEmbed("/", fsys, test.options...)
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.url, nil)
+			assert.Nil(t, err)
+			for key, values := range test.header {
+				req.Header[key] = values
+			}
+			route.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectBody != "" {
+				assert.Equal(t, test.expectBody, w.Body.String())
+			}
+			if test.expectLinks != nil {
+				assert.Equal(t, len(test.expectLinks), strings.Count(w.Body.String(), "<a href="))
+				for _, link := range test.expectLinks {
+					assert.Contains(t, w.Body.String(), "<a href=\""+link+"\">")
+				}
+			}
+			for key, value := range test.expectHeader {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
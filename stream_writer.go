@@ -1,7 +1,9 @@
 package chi
 
 import (
+	"context"
 	"io"
+	"net/http"
 
 	"github.com/go-rat/chix"
 )
@@ -9,10 +11,16 @@ import (
 type StreamWriter struct {
 	render *chix.Render
 	w      io.Writer
+	ctx    context.Context
 }
 
 func NewStreamWriter(render *chix.Render, w io.Writer) *StreamWriter {
-	return &StreamWriter{render, w}
+	return &StreamWriter{render: render, w: w, ctx: context.Background()}
+}
+
+// Context is the context of the request, it's done when the client disconnects.
+func (w *StreamWriter) Context() context.Context {
+	return w.ctx
 }
 
 func (w *StreamWriter) Flush() error {
@@ -20,6 +28,14 @@ func (w *StreamWriter) Flush() error {
 	return nil
 }
 
+// SetTrailer sets an HTTP trailer sent after the body, such as a checksum of the streamed data. It
+// doesn't need to be declared with StreamResponse.Trailer first.
+func (w *StreamWriter) SetTrailer(key, value string) {
+	if rw, ok := w.w.(http.ResponseWriter); ok {
+		rw.Header().Set(http.TrailerPrefix+key, value)
+	}
+}
+
 func (w *StreamWriter) Write(data []byte) (int, error) {
 	return w.w.Write(data)
 }
@@ -0,0 +1,111 @@
+package chi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	logmocks "github.com/goravel/framework/mocks/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStreamResponse_Trailer(t *testing.T) {
+	route := newTestRoute(t)
+	route.Get("/stream", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Stream(http.StatusOK, func(w contractshttp.StreamWriter) error {
+			writer := w.(*StreamWriter)
+			assert.Equal(t, ctx.Context(), writer.Context())
+
+			hash := sha256.New()
+			if _, err := io.MultiWriter(w, hash).Write([]byte("goravel")); err != nil {
+				return err
+			}
+			writer.SetTrailer("Checksum", hex.EncodeToString(hash.Sum(nil)))
+			writer.SetTrailer("Stream-Status", "complete")
+
+			return nil
+		}).(*StreamResponse).Trailer("Checksum")
+	})
+	server := httptest.NewServer(route)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/stream")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	// The client moves the declared trailers from the Trailer header to the keys of Trailer.
+	_, declared := response.Trailer["Checksum"]
+	assert.True(t, declared)
+
+	body, err := io.ReadAll(response.Body)
+	assert.Nil(t, err)
+	sum := sha256.Sum256([]byte("goravel"))
+	assert.Equal(t, "goravel", string(body))
+	assert.Equal(t, hex.EncodeToString(sum[:]), response.Trailer.Get("Checksum"))
+	assert.Equal(t, "complete", response.Trailer.Get("Stream-Status"))
+}
+
+func TestStreamResponse_Error(t *testing.T) {
+	errStream := errors.New("stream failed")
+	route := newTestRoute(t)
+
+	mockWriter := &logmocks.Writer{}
+	mockWriter.On("Errorf", "stream %s %s error: %v", http.MethodGet, "/stream", errStream).Return().Once()
+	mockLog := &logmocks.Log{}
+	mockLog.On("WithContext", mock.Anything).Return(mockWriter).Once()
+	LogFacade = mockLog
+
+	var middlewareErr error
+	route.Middleware(func(ctx contractshttp.Context) {
+		ctx.Request().Next()
+		middlewareErr = ctx.Response().(*ContextResponse).Error()
+	}).Get("/stream", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Stream(http.StatusOK, func(w contractshttp.StreamWriter) error {
+			if _, err := w.WriteString("gor"); err != nil {
+				return err
+			}
+
+			return errStream
+		})
+	})
+
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gor", w.Body.String())
+	assert.Equal(t, errStream, middlewareErr)
+	mockLog.AssertExpectations(t)
+	mockWriter.AssertExpectations(t)
+}
+
+func TestStreamResponse_Canceled(t *testing.T) {
+	route := newTestRoute(t)
+
+	var middlewareErr error
+	called := false
+	route.Middleware(func(ctx contractshttp.Context) {
+		ctx.Request().Next()
+		middlewareErr = ctx.Response().(*ContextResponse).Error()
+	}).Get("/stream", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Stream(http.StatusOK, func(w contractshttp.StreamWriter) error {
+			called = true
+
+			return nil
+		})
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w := httptest.NewRecorder()
+	route.ServeHTTP(w, httptest.NewRequest("GET", "/stream", nil).WithContext(ctx))
+
+	assert.False(t, called)
+	assert.ErrorIs(t, middlewareErr, context.Canceled)
+}
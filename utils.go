@@ -2,10 +2,13 @@ package chi
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/goravel/framework/contracts/config"
 	httpcontract "github.com/goravel/framework/contracts/http"
@@ -23,22 +26,142 @@ func middlewaresToChiHandlers(instance *Instance, middlewares []httpcontract.Mid
 func handlerToChiHandler(instance *Instance, handler httpcontract.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// TODO if not copy request, the request body will be empty in the next handler?
+		ctx := &Context{r: copyRequest(r), w: w, instance: instance}
+		if !bindRouteModels(ctx) {
+			return
+		}
+		if response := handler(ctx); response != nil {
+			setResponseError(r, response.Render())
+		}
+	}
+}
+
+// fallbackToChiHandler is like handlerToChiHandler for the handlers of requests without a matched
+// route, they don't resolve route bindings since a failed binding may call them.
+func fallbackToChiHandler(instance *Instance, handler httpcontract.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 		if response := handler(NewContext(instance, w, copyRequest(r))); response != nil {
-			_ = response.Render()
+			setResponseError(r, response.Render())
 		}
 	}
 }
 
+type responseErrorKey struct{}
+
+// responseError holds the error of rendering the response, trackResponseError shares it with the
+// contexts of every middleware of the request.
+type responseError struct {
+	err error
+}
+
+func trackResponseError(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), responseErrorKey{}, &responseError{})))
+	})
+}
+
+func setResponseError(r *http.Request, err error) {
+	if holder, ok := r.Context().Value(responseErrorKey{}).(*responseError); ok && err != nil {
+		holder.err = err
+	}
+}
+
 func middlewareToChiHandler(instance *Instance, handler httpcontract.Middleware) func(http.Handler) http.Handler {
+	return middlewareToChiHandlerWithCopy(instance, handler, copyRequest)
+}
+
+// globalMiddlewaresToChiHandlers is like middlewaresToChiHandlers without reading the bodies of the
+// requests routed to a proxy in advance, so they're streamed to the upstream.
+func globalMiddlewaresToChiHandlers(instance *Instance, middlewares []httpcontract.Middleware) []func(http.Handler) http.Handler {
+	var handlers []func(http.Handler) http.Handler
+	for _, item := range middlewares {
+		handlers = append(handlers, middlewareToChiHandlerWithCopy(instance, item, func(r *http.Request) *http.Request {
+			if instance.isProxyRequest(r) {
+				return r.Clone(r.Context())
+			}
+
+			return copyRequest(r)
+		}))
+	}
+
+	return handlers
+}
+
+// streamingMiddlewaresToChiHandlers is like middlewaresToChiHandlers without reading request bodies
+// in advance, so they're streamed to the handler unless a middleware reads the body input.
+func streamingMiddlewaresToChiHandlers(instance *Instance, middlewares []httpcontract.Middleware) []func(http.Handler) http.Handler {
+	var handlers []func(http.Handler) http.Handler
+	for _, item := range middlewares {
+		handlers = append(handlers, middlewareToChiHandlerWithCopy(instance, item, func(r *http.Request) *http.Request {
+			return r.Clone(r.Context())
+		}))
+	}
+
+	return handlers
+}
+
+func middlewareToChiHandlerWithCopy(instance *Instance, handler httpcontract.Middleware, clone func(r *http.Request) *http.Request) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// TODO if not copy request, the request body will be empty in the next middleware?
-			handler(NewContext(instance, w, copyRequest(r)))
-			next.ServeHTTP(w, r)
+			ctx := &Context{r: clone(r), w: w, instance: instance}
+			ctx.next = func() {
+				next.ServeHTTP(ctx.w, ctx.r)
+			}
+
+			handler(ctx)
+			if !ctx.aborted {
+				ctx.callNext()
+			}
 		})
 	}
 }
 
+// routeMethods are the methods routes can be registered with, in the order of the Allow header.
+var routeMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// routeExists reports whether the mux has a route for the path, with the method or another one so
+// the request is answered with the Allow header of the path.
+func routeExists(mux *chi.Mux, method, path string) bool {
+	if mux.Match(chi.NewRouteContext(), method, path) {
+		return true
+	}
+	for _, item := range routeMethods {
+		if mux.Match(chi.NewRouteContext(), item, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowedMethods lists the methods of the routes matching the path of the request.
+func allowedMethods(mux *chi.Mux, r *http.Request) []string {
+	path := r.URL.RawPath
+	if path == "" {
+		path = r.URL.Path
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+		path = rctx.RoutePath
+	}
+
+	var methods []string
+	for _, method := range routeMethods {
+		if mux.Match(chi.NewRouteContext(), method, path) {
+			methods = append(methods, method)
+		}
+	}
+	// GET routes answer HEAD requests too.
+	if len(methods) > 0 && methods[0] == http.MethodGet && (len(methods) == 1 || methods[1] != http.MethodHead) {
+		methods = slices.Insert(methods, 1, http.MethodHead)
+	}
+
+	return methods
+}
+
 func getDebugLog(config config.Config) func(next http.Handler) http.Handler {
 	if config.GetBool("app.debug") {
 		return middleware.Logger
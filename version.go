@@ -0,0 +1,177 @@
+package chi
+
+import (
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	httpcontract "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+)
+
+type VersioningOptions struct {
+	// Header is the request header carrying the version of requests without a version prefix, such
+	// as X-API-Version. The Accept header is used too, e.g. application/vnd.goravel.v2+json or
+	// application/json; version=v2.
+	Header string
+	// Default is the version of requests that don't specify one, they only match the routes without a
+	// version when it's empty.
+	Default string
+	// Fallback routes requests for a path their version doesn't have to the newest older version that
+	// has it, versions are ordered by their registration.
+	Fallback bool
+}
+
+type VersionOptions struct {
+	// Deprecation marks the version as deprecated since the time with the Deprecation header.
+	Deprecation time.Time
+	// Sunset is the time the version will stop responding, set in the Sunset header.
+	Sunset time.Time
+	// Link is a URL documenting the deprecation, set in the Link header with the deprecation relation.
+	Link string
+}
+
+// vendorVersionRegexp matches the version of vendor media types such as application/vnd.goravel.v2+json.
+var vendorVersionRegexp = regexp.MustCompile(`^[^/]+/vnd\.[^+]+\.(v[^.+]+)(?:\+[^+]+)?$`)
+
+// Versioning configures how the version of requests without a version prefix is selected.
+func (r *Route) Versioning(options VersioningOptions) {
+	r.instance.versioning = options
+}
+
+// Version registers the routes of an API version under its prefix, such as /v2/users. Requests
+// without the prefix are routed to the version of their header, Accept header or the default of
+// Versioning. Register versions from the oldest to the newest.
+func (r *Route) Version(version string, handler route.GroupFunc, options ...VersionOptions) {
+	version = strings.Trim(version, "/")
+	if !slices.Contains(r.instance.versions, version) {
+		r.instance.versions = append(r.instance.versions, version)
+	}
+
+	var middlewares []httpcontract.Middleware
+	if len(options) > 0 && (!options[0].Deprecation.IsZero() || !options[0].Sunset.IsZero()) {
+		middlewares = append(middlewares, deprecation(options[0]))
+	}
+
+	handler(NewGroup(r.config, r.instance, "/"+version, middlewares, []httpcontract.Middleware{ResponseMiddleware()}))
+}
+
+// deprecation sets the RFC 9745 Deprecation and RFC 8594 Sunset headers of a version.
+func deprecation(options VersionOptions) httpcontract.Middleware {
+	return func(ctx httpcontract.Context) {
+		if !options.Deprecation.IsZero() {
+			ctx.Response().Header("Deprecation", "@"+strconv.FormatInt(options.Deprecation.Unix(), 10))
+			if options.Link != "" {
+				ctx.Response().Header("Link", "<"+options.Link+`>; rel="deprecation"; type="text/html"`)
+			}
+		}
+		if !options.Sunset.IsZero() {
+			ctx.Response().Header("Sunset", options.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		ctx.Request().Next()
+	}
+}
+
+// dispatchVersion routes requests to the routes of their version, falling back to older versions
+// when enabled. Requests without a version prefix keep their path when their version has no route
+// for it, so they can match the routes without a version.
+func (i *Instance) dispatchVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		if len(i.versions) == 0 || rctx == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		routePath := rctx.RoutePath
+		if routePath == "" {
+			routePath = r.URL.RawPath
+			if routePath == "" {
+				routePath = r.URL.Path
+			}
+		}
+		if strings.HasPrefix(routePath, domainRoutePrefix+"/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		version, rest := "", routePath
+		for _, item := range i.versions {
+			if trimmed, found := strings.CutPrefix(routePath, "/"+item); found && (trimmed == "" || trimmed[0] == '/') {
+				version, rest = item, trimmed
+				break
+			}
+		}
+		if version != "" {
+			if !i.versioning.Fallback {
+				next.ServeHTTP(w, r)
+				return
+			}
+		} else {
+			version = i.requestVersion(w, r)
+		}
+
+		index := slices.Index(i.versions, version)
+		if index == -1 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rest = path.Clean("/" + rest)
+		for ; index >= 0; index-- {
+			versionPath := "/" + i.versions[index] + rest
+			if rest == "/" {
+				versionPath = "/" + i.versions[index]
+			}
+			if routeExists(i.mux, r.Method, versionPath) {
+				rctx.RoutePath = versionPath
+				break
+			}
+			if !i.versioning.Fallback {
+				break
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestVersion returns the version of a request without a version prefix, the response varies by
+// the headers it's read from.
+func (i *Instance) requestVersion(w http.ResponseWriter, r *http.Request) string {
+	if i.versioning.Header != "" {
+		w.Header().Add("Vary", i.versioning.Header)
+		if version := strings.TrimSpace(r.Header.Get(i.versioning.Header)); version != "" {
+			return i.normalizeVersion(version)
+		}
+	}
+
+	if accept := r.Header.Get("Accept"); accept != "" {
+		w.Header().Add("Vary", "Accept")
+		for _, item := range parseAccept(accept) {
+			if version, exist := item.params["version"]; exist {
+				return i.normalizeVersion(version)
+			}
+			if matches := vendorVersionRegexp.FindStringSubmatch(item.mediaType); matches != nil {
+				return i.normalizeVersion(matches[1])
+			}
+		}
+	}
+
+	return i.versioning.Default
+}
+
+// normalizeVersion matches versions like 2 to the registered v2.
+func (i *Instance) normalizeVersion(version string) string {
+	if !slices.Contains(i.versions, version) && slices.Contains(i.versions, "v"+version) {
+		return "v" + version
+	}
+
+	return version
+}
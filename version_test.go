@@ -0,0 +1,173 @@
+package chi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/route"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	deprecatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		options      VersioningOptions
+		url          string
+		header       http.Header
+		expectCode   int
+		expectBody   string
+		expectHeader map[string]string
+	}{
+		{
+			name:       "prefix",
+			url:        "/v2/users",
+			expectCode: http.StatusOK,
+			expectBody: "v2 users",
+		},
+		{
+			name:       "prefix without fallback",
+			url:        "/v2/posts",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "prefix with fallback",
+			options:    VersioningOptions{Fallback: true},
+			url:        "/v2/posts",
+			expectCode: http.StatusOK,
+			expectBody: "v1 posts",
+		},
+		{
+			name:       "header",
+			options:    VersioningOptions{Header: "X-API-Version"},
+			url:        "/users",
+			header:     http.Header{"X-Api-Version": {"2"}},
+			expectCode: http.StatusOK,
+			expectBody: "v2 users",
+			expectHeader: map[string]string{
+				"Vary": "X-API-Version",
+			},
+		},
+		{
+			name:       "vendor media type",
+			url:        "/users",
+			header:     http.Header{"Accept": {"application/vnd.goravel.v2+json"}},
+			expectCode: http.StatusOK,
+			expectBody: "v2 users",
+			expectHeader: map[string]string{
+				"Vary": "Accept",
+			},
+		},
+		{
+			name:       "media type parameter",
+			url:        "/users",
+			header:     http.Header{"Accept": {"application/json; version=v1"}},
+			expectCode: http.StatusOK,
+			expectBody: "v1 users",
+		},
+		{
+			name:       "default",
+			options:    VersioningOptions{Default: "v2"},
+			url:        "/users",
+			expectCode: http.StatusOK,
+			expectBody: "v2 users",
+		},
+		{
+			name:       "default with fallback",
+			options:    VersioningOptions{Default: "v2", Fallback: true},
+			url:        "/posts",
+			expectCode: http.StatusOK,
+			expectBody: "v1 posts",
+		},
+		{
+			name:       "route without version",
+			options:    VersioningOptions{Default: "v2"},
+			url:        "/health",
+			expectCode: http.StatusOK,
+			expectBody: "ok",
+		},
+		{
+			name:       "no version",
+			url:        "/users",
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "unknown version",
+			url:        "/users",
+			header:     http.Header{"Accept": {"application/vnd.goravel.v9+json"}},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "deprecated version",
+			url:        "/v1/users",
+			expectCode: http.StatusOK,
+			expectBody: "v1 users",
+			expectHeader: map[string]string{
+				"Deprecation": "@1704067200",
+				"Sunset":      "Wed, 01 Jan 2025 00:00:00 GMT",
+				"Link":        `<https://goravel.dev/v1>; rel="deprecation"; type="text/html"`,
+			},
+		},
+		{
+			name:       "current version",
+			url:        "/v2/users",
+			expectCode: http.StatusOK,
+			expectHeader: map[string]string{
+				"Deprecation": "",
+				"Sunset":      "",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockConfig := &configmocks.Config{}
+			mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+			mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+			r, err := NewRoute(mockConfig, nil)
+			assert.Nil(t, err)
+			r.Versioning(test.options)
+			r.Get("/health", func(ctx contractshttp.Context) contractshttp.Response {
+				return ctx.Response().String(http.StatusOK, "ok")
+			})
+			r.Version("v1", func(router route.Router) {
+				router.Get("/users", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "v1 users")
+				})
+				router.Get("/posts", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "v1 posts")
+				})
+			}, VersionOptions{Deprecation: deprecatedAt, Sunset: sunsetAt, Link: "https://goravel.dev/v1"})
+			r.Version("v2", func(router route.Router) {
+				router.Get("/users", func(ctx contractshttp.Context) contractshttp.Response {
+					return ctx.Response().String(http.StatusOK, "v2 users")
+				})
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.url, nil)
+			assert.Nil(t, err)
+			for key, values := range test.header {
+				req.Header[key] = values
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectCode, w.Code)
+			if test.expectBody != "" {
+				assert.Equal(t, test.expectBody, w.Body.String())
+			}
+			for key, value := range test.expectHeader {
+				assert.Equal(t, value, w.Header().Get(key), key)
+			}
+
+			mockConfig.AssertExpectations(t)
+		})
+	}
+}
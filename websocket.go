@@ -0,0 +1,185 @@
+package chi
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WebSocketTextMessage   = websocket.TextMessage
+	WebSocketBinaryMessage = websocket.BinaryMessage
+)
+
+// webSocketControlTimeout bounds the writes of ping and close frames.
+const webSocketControlTimeout = 10 * time.Second
+
+type WebSocketOptions struct {
+	// Subprotocols are the supported subprotocols in order of preference, the first one the client
+	// requests too is selected.
+	Subprotocols []string
+	// EnableCompression negotiates per-message deflate compression with clients supporting it.
+	EnableCompression bool
+	// ReadLimit is the maximum size in bytes of a message read from the client, 0 means no limit.
+	ReadLimit int64
+	// HandshakeTimeout bounds the upgrade handshake.
+	HandshakeTimeout time.Duration
+	// ReadBufferSize and WriteBufferSize are the sizes of the I/O buffers, default is 4096.
+	ReadBufferSize  int
+	WriteBufferSize int
+	// AllowedOrigins are the cross-site origins allowed besides the same host, such as
+	// "https://app.goravel.dev" or "https://*.goravel.dev". Default is cors.allowed_origins unless it
+	// contains "*", the default of the CORS config, which would expose connections to cross-site
+	// WebSocket hijacking.
+	AllowedOrigins []string
+	// CheckOrigin decides whether the Origin of the request is allowed. Default allows requests without
+	// an Origin, from the same host and from AllowedOrigins.
+	CheckOrigin func(ctx contractshttp.Context) bool
+}
+
+// WebSocketHandler serves a WebSocket connection, the connection is closed when it returns.
+type WebSocketHandler func(ctx contractshttp.Context, conn *WebSocketConn)
+
+// WebSocketConn is a WebSocket connection, see github.com/gorilla/websocket for reading and writing
+// messages, ping and pong handlers and close codes.
+type WebSocketConn struct {
+	*websocket.Conn
+}
+
+// Ping sends a ping frame, the pong of the client is received by the pong handler while reading.
+func (c *WebSocketConn) Ping(data []byte) error {
+	return c.WriteControl(websocket.PingMessage, data, time.Now().Add(webSocketControlTimeout))
+}
+
+// CloseWithStatus sends a close frame with the code, such as websocket.CloseNormalClosure, and the
+// reason before closing the connection.
+func (c *WebSocketConn) CloseWithStatus(code int, reason string) error {
+	err := c.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(webSocketControlTimeout))
+	if closeErr := c.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// WebSocket registers a GET route upgrading requests to WebSocket connections served by the handler,
+// after the middlewares of the group. Requests that can't be upgraded are answered with 400, and
+// with 403 when their origin isn't allowed.
+func (r *Group) WebSocket(relativePath string, handler WebSocketHandler, options ...WebSocketOptions) {
+	option := WebSocketOptions{}
+	if len(options) > 0 {
+		option = options[0]
+	}
+
+	instance := r.instance
+	r.Get(relativePath, func(ctx contractshttp.Context) contractshttp.Response {
+		c, ok := ctx.(*Context)
+		if !ok {
+			return nil
+		}
+
+		upgrader := websocket.Upgrader{
+			HandshakeTimeout:  option.HandshakeTimeout,
+			ReadBufferSize:    option.ReadBufferSize,
+			WriteBufferSize:   option.WriteBufferSize,
+			Subprotocols:      option.Subprotocols,
+			EnableCompression: option.EnableCompression,
+			Error: func(w http.ResponseWriter, req *http.Request, status int, reason error) {
+				abortWithStatus(instance, w, req, status)
+			},
+			CheckOrigin: func(req *http.Request) bool {
+				if option.CheckOrigin != nil {
+					return option.CheckOrigin(ctx)
+				}
+
+				return checkWebSocketOrigin(req, option)
+			},
+		}
+
+		conn, err := upgrader.Upgrade(&webSocketWriter{ResponseWriter: c.w}, c.r, nil)
+		if err != nil {
+			// The upgrader answered the request.
+			return nil
+		}
+		defer conn.Close()
+
+		if option.ReadLimit > 0 {
+			conn.SetReadLimit(option.ReadLimit)
+		}
+		handler(ctx, &WebSocketConn{Conn: conn})
+
+		return nil
+	})
+}
+
+// webSocketAllowedOrigins returns the AllowedOrigins option, or cors.allowed_origins when the option
+// is empty and the CORS config doesn't allow every origin.
+func webSocketAllowedOrigins(option WebSocketOptions) []string {
+	if len(option.AllowedOrigins) > 0 || ConfigFacade == nil {
+		return option.AllowedOrigins
+	}
+
+	allowedOrigins, ok := ConfigFacade.Get("cors.allowed_origins").([]string)
+	if !ok || slices.Contains(allowedOrigins, "*") {
+		return nil
+	}
+
+	return allowedOrigins
+}
+
+// checkWebSocketOrigin allows requests without an Origin header, from the same host or from one of
+// the allowed origins, which may contain a * wildcard.
+func checkWebSocketOrigin(r *http.Request, option WebSocketOptions) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(originURL.Host, r.Host) {
+		return true
+	}
+
+	for _, allowedOrigin := range webSocketAllowedOrigins(option) {
+		if matchOrigin(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchOrigin(pattern, origin string) bool {
+	pattern, origin = strings.ToLower(pattern), strings.ToLower(origin)
+	if pattern == "*" {
+		return true
+	}
+	if prefix, suffix, found := strings.Cut(pattern, "*"); found {
+		return len(origin) >= len(prefix)+len(suffix) && strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix)
+	}
+
+	return pattern == origin
+}
+
+// webSocketWriter hijacks the connection through the writers wrapping the response, which don't all
+// implement http.Hijacker themselves.
+type webSocketWriter struct {
+	http.ResponseWriter
+}
+
+func (w *webSocketWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func (w *webSocketWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,134 @@
+package chi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	configmocks "github.com/goravel/framework/mocks/config"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebSocket(t *testing.T) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().GetBool("app.debug").Return(false).Once()
+	mockConfig.EXPECT().GetInt("http.drivers.chi.body_limit", 4096).Return(4096).Once()
+
+	route, err := NewRoute(mockConfig, nil)
+	assert.Nil(t, err)
+	route.Router.(*Group).WebSocket("/echo/{name}", func(ctx contractshttp.Context, conn *WebSocketConn) {
+		for {
+			messageType, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(message) == "bye" {
+				_ = conn.CloseWithStatus(4000, "bye "+ctx.Request().Route("name"))
+				return
+			}
+			if err := conn.WriteMessage(messageType, append([]byte(conn.Subprotocol()+" "), message...)); err != nil {
+				return
+			}
+		}
+	}, WebSocketOptions{
+		Subprotocols:      []string{"v2.goravel", "v1.goravel"},
+		EnableCompression: true,
+		AllowedOrigins:    []string{"https://*.goravel.dev"},
+	})
+	route.Router.(*Group).WebSocket("/default", func(ctx contractshttp.Context, conn *WebSocketConn) {})
+	server := httptest.NewServer(route)
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/echo/goravel"
+
+	t.Run("messages", func(t *testing.T) {
+		dialer := websocket.Dialer{Subprotocols: []string{"v1.goravel", "v2.goravel"}, EnableCompression: true}
+		conn, response, err := dialer.Dial(url, nil)
+		assert.Nil(t, err)
+		defer conn.Close()
+		assert.Equal(t, "v2.goravel", response.Header.Get("Sec-WebSocket-Protocol"))
+		assert.Contains(t, response.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+		assert.Nil(t, conn.WriteMessage(websocket.TextMessage, []byte("hello")))
+		messageType, message, err := conn.ReadMessage()
+		assert.Nil(t, err)
+		assert.Equal(t, websocket.TextMessage, messageType)
+		assert.Equal(t, "v2.goravel hello", string(message))
+
+		pong := make(chan string, 1)
+		conn.SetPongHandler(func(data string) error {
+			pong <- data
+			return nil
+		})
+		assert.Nil(t, conn.WriteMessage(websocket.PingMessage, []byte("ping")))
+		assert.Nil(t, conn.WriteMessage(websocket.BinaryMessage, []byte("bye")))
+		_, _, err = conn.ReadMessage()
+		assert.Equal(t, "ping", <-pong)
+
+		var closeErr *websocket.CloseError
+		assert.True(t, errors.As(err, &closeErr))
+		assert.Equal(t, 4000, closeErr.Code)
+		assert.Equal(t, "bye goravel", closeErr.Text)
+	})
+
+	t.Run("allowed origin", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://app.goravel.dev"}})
+		assert.Nil(t, err)
+		_ = conn.Close()
+	})
+
+	t.Run("same origin", func(t *testing.T) {
+		conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {server.URL}})
+		assert.Nil(t, err)
+		_ = conn.Close()
+	})
+
+	t.Run("forbidden origin", func(t *testing.T) {
+		_, response, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://goravel.example.com"}})
+		assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("cross-site origin is forbidden by default", func(t *testing.T) {
+		mockCorsConfig(t, []string{"*"})
+		_, response, err := websocket.DefaultDialer.Dial(strings.Replace(url, "/echo/goravel", "/default", 1), http.Header{"Origin": {"https://app.goravel.dev"}})
+		assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("origin allowed by the CORS config", func(t *testing.T) {
+		mockCorsConfig(t, []string{"https://app.goravel.dev"})
+		conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(url, "/echo/goravel", "/default", 1), http.Header{"Origin": {"https://app.goravel.dev"}})
+		assert.Nil(t, err)
+		_ = conn.Close()
+	})
+
+	t.Run("not an upgrade", func(t *testing.T) {
+		response, err := http.Get(server.URL + "/echo/goravel")
+		assert.Nil(t, err)
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
+
+func mockCorsConfig(t *testing.T, allowedOrigins []string) {
+	mockConfig := &configmocks.Config{}
+	mockConfig.EXPECT().Get("cors.allowed_origins").Return(allowedOrigins).Once()
+	originConfigFacade := ConfigFacade
+	ConfigFacade = mockConfig
+	t.Cleanup(func() {
+		ConfigFacade = originConfigFacade
+		mockConfig.AssertExpectations(t)
+	})
+}
+
+func TestMatchOrigin(t *testing.T) {
+	assert.True(t, matchOrigin("*", "https://goravel.dev"))
+	assert.True(t, matchOrigin("https://goravel.dev", "https://Goravel.dev"))
+	assert.True(t, matchOrigin("https://*.goravel.dev", "https://app.goravel.dev"))
+	assert.False(t, matchOrigin("https://*.goravel.dev", "https://goravel.dev"))
+	assert.False(t, matchOrigin("https://goravel.dev", "https://goravel.dev.example.com"))
+}